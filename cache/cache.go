@@ -4,9 +4,96 @@
 
 package cache
 
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+)
+
+// Cache is a generic key-value store organized in named maps.
+// The methods with a Ctx suffix use the given context for deadlines, cancellation and tracing,
+// while the others fall back to a context of the implementation.
+// Entries written with a TTL are removed once it elapsed, entries written with a max idle time
+// are removed if they have not been accessed for that long. A duration of zero disables the respective expiry.
+// GetAll only contains the keys that exist in the map. Values that cannot be unmarshalled are reported
+// using a *BatchError, while all other values are still returned.
+// Listeners stay registered until they are removed using the returned ListenerHandle.
 type Cache[T any] interface {
 	Put(mapName string, key string, value T) error
+	PutCtx(ctx context.Context, mapName string, key string, value T) error
+	PutWithTTL(mapName string, key string, value T, ttl time.Duration) error
+	PutWithTTLCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration) error
+	PutWithTTLAndMaxIdle(mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error
+	PutWithTTLAndMaxIdleCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error
 	Get(mapName string, key string) (*T, error)
+	GetCtx(ctx context.Context, mapName string, key string) (*T, error)
 	Delete(mapName string, key string) error
-	AddListener(mapName string, listener Listener[T]) error
+	DeleteCtx(ctx context.Context, mapName string, key string) error
+	PutAll(mapName string, values map[string]T) error
+	PutAllCtx(ctx context.Context, mapName string, values map[string]T) error
+	GetAll(mapName string, keys []string) (map[string]T, error)
+	GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error)
+	DeleteAll(mapName string, keys []string) error
+	DeleteAllCtx(ctx context.Context, mapName string, keys []string) error
+	AddListener(mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error)
+	AddListenerCtx(ctx context.Context, mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error)
+	AddListenerForKey(mapName string, key string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error)
+	AddListenerForKeyCtx(
+		ctx context.Context,
+		mapName string,
+		key string,
+		listener Listener[T],
+		opts ...ListenerOption,
+	) (ListenerHandle, error)
+	RemoveListener(handle ListenerHandle) error
+	RemoveListenerCtx(ctx context.Context, handle ListenerHandle) error
+}
+
+// QueryableCache is a Cache whose entries can be queried using Hazelcast predicates.
+// Paged queries and iterators load the keys of all matching entries, but fetch the values page by page.
+// They reflect the entries that matched when the query was started; entries removed in the meantime are skipped.
+// QueryIter stops silently on errors, QueryIterCtx returns a function to inspect the error once iteration stopped.
+// Listeners registered with a predicate only receive events of entries matching it. The predicate is evaluated
+// against the new value of added and updated entries and against the old value of removed entries.
+type QueryableCache[T any] interface {
+	Cache[T]
+	GetQuery(mapName string, query predicate.Predicate) ([]T, error)
+	GetQueryCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]T, error)
+	GetQueryEntries(mapName string, query predicate.Predicate) ([]Entry[T], error)
+	GetQueryEntriesCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]Entry[T], error)
+	GetQueryKeys(mapName string, query predicate.Predicate) ([]string, error)
+	GetQueryKeysCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]string, error)
+	GetQueryPage(mapName string, query predicate.Predicate, page int, pageSize int) (*Page[T], error)
+	GetQueryPageCtx(ctx context.Context, mapName string, query predicate.Predicate, page int, pageSize int) (*Page[T], error)
+	QueryIter(mapName string, query predicate.Predicate) iter.Seq2[string, T]
+	QueryIterCtx(ctx context.Context, mapName string, query predicate.Predicate, pageSize int) (iter.Seq2[string, T], func() error)
+	AddListenerWithPredicate(
+		mapName string,
+		query predicate.Predicate,
+		listener Listener[T],
+		opts ...ListenerOption,
+	) (ListenerHandle, error)
+	AddListenerWithPredicateCtx(
+		ctx context.Context,
+		mapName string,
+		query predicate.Predicate,
+		listener Listener[T],
+		opts ...ListenerOption,
+	) (ListenerHandle, error)
+}
+
+// Entry is a single key-value pair of a cache map.
+type Entry[T any] struct {
+	Key   string
+	Value T
+}
+
+func entryValues[T any](entries []Entry[T]) []T {
+	values := make([]T, 0, len(entries))
+	for _, entry := range entries {
+		values = append(values, entry.Value)
+	}
+	return values
 }
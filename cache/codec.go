@@ -0,0 +1,159 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+// Codec converts values to and from the representation that is stored in a cache.
+type Codec[T any] interface {
+	Encode(value T) (any, error)
+	Decode(data any) (T, error)
+}
+
+// ErrNotJSON is returned by JSONCodec if a cached value is not a HazelcastJsonValue.
+var ErrNotJSON = errors.New("value is not a HazelcastJsonValue")
+
+// JSONCodec stores values as HazelcastJsonValue using encoding/json.
+// It is the default codec and allows querying values by their JSON attributes.
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(value T) (any, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return serialization.JSON(bytes), nil
+}
+
+func (JSONCodec[T]) Decode(data any) (T, error) {
+	var value T
+
+	hzJsonValue, ok := data.(serialization.JSON)
+	if !ok {
+		return value, ErrNotJSON
+	}
+
+	err := json.Unmarshal(hzJsonValue, &value)
+	return value, err
+}
+
+// BytesCodec stores raw bytes without any conversion. Values stored this way cannot be queried.
+type BytesCodec struct{}
+
+func (BytesCodec) Encode(value []byte) (any, error) {
+	return value, nil
+}
+
+func (BytesCodec) Decode(data any) ([]byte, error) {
+	bytes, ok := data.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("value of type %T is not a byte array", data)
+	}
+	return bytes, nil
+}
+
+// NativeCodec passes values to the Hazelcast client as they are, so they are serialized by the serializers
+// registered in the client configuration, e.g. compact or portable serializers shared with Java services.
+// Hazelcast can index and query such values as long as the serializer supports it.
+type NativeCodec[T any] struct{}
+
+func (NativeCodec[T]) Encode(value T) (any, error) {
+	return value, nil
+}
+
+func (NativeCodec[T]) Decode(data any) (T, error) {
+	switch value := data.(type) {
+	case T:
+		return value, nil
+	case *T:
+		if value != nil {
+			return *value, nil
+		}
+	}
+
+	var zero T
+	return zero, fmt.Errorf("value of type %T cannot be converted to %T", data, zero)
+}
+
+// Option configures a HazelcastCache or MemoryCache.
+type Option[T any] func(*options[T])
+
+type options[T any] struct {
+	codec       Codec[T]
+	clusterName string
+
+	notFoundError      bool
+	decodeErrorHandler func(err *DecodeError)
+}
+
+func newOptions[T any](opts []Option[T]) options[T] {
+	o := options[T]{
+		codec: JSONCodec[T]{},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithCodec sets the codec used to store values. By default, values are stored as JSON.
+func WithCodec[T any](codec Codec[T]) Option[T] {
+	return func(o *options[T]) {
+		o.codec = codec
+	}
+}
+
+// WithNotFoundError makes Get return ErrNotFound on a cache miss instead of a nil value. This also applies to replicated maps.
+func WithNotFoundError[T any]() Option[T] {
+	return func(o *options[T]) {
+		o.notFoundError = true
+	}
+}
+
+// WithDecodeErrorHandler makes reads of multiple entries skip entries that cannot be decoded and pass their errors
+// to handler instead of failing. This applies to GetAll and all queries, including pages, iterators and Export,
+// as well as to the entries of replicated maps and the values of multi-maps.
+func WithDecodeErrorHandler[T any](handler func(err *DecodeError)) Option[T] {
+	return func(o *options[T]) {
+		o.decodeErrorHandler = handler
+	}
+}
+
+// miss returns the result of Get for a key that does not exist.
+func (o *options[T]) miss() (*T, error) {
+	if o.notFoundError {
+		return nil, ErrNotFound
+	}
+
+	//nolint:nilnil // Cache miss is a valid state, not an error; also comply with api
+	return nil, nil
+}
+
+// skipDecodeError reports whether a read of multiple entries skips an entry that failed to decode,
+// in which case the error is passed to the handler.
+func (o *options[T]) skipDecodeError(err error) bool {
+	var decodeErr *DecodeError
+	if o.decodeErrorHandler == nil || !errors.As(err, &decodeErr) {
+		return false
+	}
+
+	o.decodeErrorHandler(decodeErr)
+	return true
+}
+
+// decode decodes a cached value and wraps errors in a DecodeError.
+func decode[T any](codec Codec[T], mapName string, key any, data any) (T, error) {
+	value, err := codec.Decode(data)
+	if err != nil {
+		return value, &DecodeError{MapName: mapName, Key: fmt.Sprint(key), Err: err}
+	}
+	return value, nil
+}
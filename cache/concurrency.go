@@ -0,0 +1,128 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+)
+
+// MaxUpdateAttempts is the number of times Update applies the update function before giving up.
+const MaxUpdateAttempts = 10
+
+var (
+	// ErrUpdateConflict is returned by Update if the entry kept changing concurrently.
+	ErrUpdateConflict = errors.New("entry was modified concurrently")
+	// ErrLockNotHeld is returned when unlocking a key that is not locked by the caller.
+	ErrLockNotHeld = errors.New("lock is not held by the caller")
+)
+
+// ConcurrentCache is a Cache that supports conditional writes and locking of single entries.
+//
+// PutIfAbsent returns the existing value if there is one, and nil if the value has been stored.
+// Replace only stores the new value if the stored value equals the encoded old value.
+// Update applies fn to the current value, which is nil if there is none, and stores the result if the entry
+// has not been modified in the meantime. Otherwise, fn is applied again, up to MaxUpdateAttempts times.
+//
+// Locks are re-entrant and owned by the lock context created using NewLockContext. A lease of zero keeps the lock
+// until it is released, otherwise it is released automatically after the lease. Contexts that have not been
+// created using NewLockContext share a default owner.
+type ConcurrentCache[T any] interface {
+	PutIfAbsent(mapName string, key string, value T) (*T, error)
+	PutIfAbsentCtx(ctx context.Context, mapName string, key string, value T) (*T, error)
+	Replace(mapName string, key string, oldValue T, newValue T) (bool, error)
+	ReplaceCtx(ctx context.Context, mapName string, key string, oldValue T, newValue T) (bool, error)
+	Update(mapName string, key string, fn func(old *T) (T, error)) (T, error)
+	UpdateCtx(ctx context.Context, mapName string, key string, fn func(old *T) (T, error)) (T, error)
+	Lock(mapName string, key string, lease time.Duration) error
+	LockCtx(ctx context.Context, mapName string, key string, lease time.Duration) error
+	TryLock(mapName string, key string, timeout time.Duration, lease time.Duration) (bool, error)
+	TryLockCtx(ctx context.Context, mapName string, key string, timeout time.Duration, lease time.Duration) (bool, error)
+	Unlock(mapName string, key string) error
+	UnlockCtx(ctx context.Context, mapName string, key string) error
+}
+
+type lockOwnerKey struct{}
+
+var lockOwnerSequence atomic.Int64
+
+// NewLockContext returns a context that identifies a lock owner. Locks acquired with it can only be released
+// using the same context or a context derived from it.
+func NewLockContext(ctx context.Context) context.Context {
+	ctx = hazelcast.NewLockContext(ctx)
+	return context.WithValue(ctx, lockOwnerKey{}, lockOwnerSequence.Add(1))
+}
+
+// lockOwner returns the lock owner of a context created using NewLockContext, or zero for the default owner.
+func lockOwner(ctx context.Context) int64 {
+	owner, _ := ctx.Value(lockOwnerKey{}).(int64)
+	return owner
+}
+
+// rawCache provides access to the encoded values of a cache, so conditional writes do not depend on
+// the encoding of the decoded value being identical to the stored one.
+type rawCache interface {
+	getRaw(ctx context.Context, mapName string, key string) (any, error)
+	putIfAbsentRaw(ctx context.Context, mapName string, key string, value any) (bool, error)
+	replaceRaw(ctx context.Context, mapName string, key string, oldValue any, newValue any) (bool, error)
+}
+
+func update[T any](
+	ctx context.Context,
+	c rawCache,
+	codec Codec[T],
+	mapName string,
+	key string,
+	fn func(old *T) (T, error),
+) (T, error) {
+	var zero T
+	for range MaxUpdateAttempts {
+		raw, err := c.getRaw(ctx, mapName, key)
+		if err != nil {
+			return zero, err
+		}
+
+		var old *T
+		if raw != nil {
+			decoded, err := decode(codec, mapName, key, raw)
+			if err != nil {
+				return zero, err
+			}
+			old = &decoded
+		}
+
+		value, err := fn(old)
+		if err != nil {
+			return zero, err
+		}
+
+		encoded, err := codec.Encode(value)
+		if err != nil {
+			return zero, fmt.Errorf("failed to encode value with key '%s': %w", key, err)
+		}
+
+		var stored bool
+		if raw == nil {
+			stored, err = c.putIfAbsentRaw(ctx, mapName, key, encoded)
+		} else {
+			stored, err = c.replaceRaw(ctx, mapName, key, raw, encoded)
+		}
+
+		if err != nil {
+			return zero, err
+		}
+
+		if stored {
+			return value, nil
+		}
+	}
+
+	return zero, fmt.Errorf("failed to update entry with key '%s': %w", key, ErrUpdateConflict)
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrCacheUnavailable is returned by a ResilientCache if an operation kept failing with retryable errors.
+	ErrCacheUnavailable = errors.New("cache is unavailable")
+	// ErrDecode is matched by every DecodeError.
+	ErrDecode = errors.New("could not decode cached object")
+	// ErrNotFound is returned by Get on a cache miss if the cache has been created using WithNotFoundError.
+	ErrNotFound = errors.New("cached object not found")
+)
+
+// DecodeError is returned if a cached value cannot be decoded by the codec.
+// Err is the cause, e.g. ErrNotJSON or a *json.UnmarshalTypeError if the JSON does not fit the value type.
+type DecodeError struct {
+	MapName string
+	Key     string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s with key '%s' in map '%s': %s", ErrDecode, e.Key, e.MapName, e.Err)
+}
+
+// Is reports whether target is ErrDecode.
+func (*DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError is returned by batch operations if some entries could not be processed.
+// Entries that have been processed successfully are still part of the result of the operation.
+type BatchError struct {
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	keys := slices.Sorted(maps.Keys(e.Errors))
+
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, fmt.Sprintf("key '%s': %s", key, e.Errors[key]))
+	}
+
+	return fmt.Sprintf("failed to process %d entries: %s", len(keys), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of all failed entries, so they can be inspected using errors.Is and errors.As.
+func (e *BatchError) Unwrap() []error {
+	return slices.Collect(maps.Values(e.Errors))
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+)
+
+type EventKind string
+
+const (
+	EventKindAdded      EventKind = "ADDED"
+	EventKindUpdated    EventKind = "UPDATED"
+	EventKindRemoved    EventKind = "REMOVED"
+	EventKindEvicted    EventKind = "EVICTED"
+	EventKindExpired    EventKind = "EXPIRED"
+	EventKindCleared    EventKind = "CLEARED"
+	EventKindMapEvicted EventKind = "MAP_EVICTED"
+)
+
+// Event describes a change of a cache map independent of the cache implementation.
+// Source identifies the cluster member that published the event and is empty if it is not known.
+// Time is the time the event was received, since Hazelcast does not transfer the time an event occurred.
+// AffectedEntries is only set for map-wide events such as EventKindCleared.
+type Event struct {
+	MapName         string
+	Key             string
+	Kind            EventKind
+	Source          string
+	Time            time.Time
+	AffectedEntries int
+}
+
+// EventListener is a backend-neutral alternative to Listener.
+// OnDelete is called for removed, evicted and expired entries; the last known value is nil if it is not available.
+// Use NewEventListenerAdapter to register it on a cache.
+type EventListener[T any] interface {
+	OnAdd(event Event, obj T)
+	OnUpdate(event Event, obj T, oldObj T)
+	OnDelete(event Event, oldObj *T)
+	OnClear(event Event)
+	OnError(event Event, err error)
+}
+
+// NewEvent converts a Hazelcast entry event into an Event.
+func NewEvent(event *hazelcast.EntryNotified) Event {
+	key, _ := event.Key.(string)
+
+	var source string
+	if !event.Member.UUID.Default() {
+		source = event.Member.String()
+	}
+
+	return Event{
+		MapName:         event.MapName,
+		Key:             key,
+		Kind:            eventKind(event.EventType),
+		Source:          source,
+		Time:            time.Now(),
+		AffectedEntries: event.NumberOfAffectedEntries,
+	}
+}
+
+func eventKind(eventType hazelcast.EntryEventType) EventKind {
+	switch eventType {
+	case hazelcast.EntryAdded:
+		return EventKindAdded
+	case hazelcast.EntryUpdated:
+		return EventKindUpdated
+	case hazelcast.EntryEvicted:
+		return EventKindEvicted
+	case hazelcast.EntryExpired:
+		return EventKindExpired
+	case hazelcast.EntryAllCleared:
+		return EventKindCleared
+	case hazelcast.EntryAllEvicted:
+		return EventKindMapEvicted
+	default:
+		return EventKindRemoved
+	}
+}
+
+// EventListenerAdapter adapts an EventListener to the Listener interface expected by AddListener.
+type EventListenerAdapter[T any] struct {
+	listener EventListener[T]
+}
+
+var _ ExtendedListener[any] = (*EventListenerAdapter[any])(nil)
+
+// NewEventListenerAdapter wraps an EventListener, so it can be registered on any Cache.
+func NewEventListenerAdapter[T any](listener EventListener[T]) *EventListenerAdapter[T] {
+	return &EventListenerAdapter[T]{listener: listener}
+}
+
+func (a *EventListenerAdapter[T]) OnAdd(event *hazelcast.EntryNotified, obj T) {
+	a.listener.OnAdd(NewEvent(event), obj)
+}
+
+func (a *EventListenerAdapter[T]) OnUpdate(event *hazelcast.EntryNotified, obj T, oldObj T) {
+	a.listener.OnUpdate(NewEvent(event), obj, oldObj)
+}
+
+func (a *EventListenerAdapter[T]) OnDelete(event *hazelcast.EntryNotified) {
+	a.listener.OnDelete(NewEvent(event), nil)
+}
+
+func (a *EventListenerAdapter[T]) OnDeleteWithValue(event *hazelcast.EntryNotified, oldObj *T) {
+	a.listener.OnDelete(NewEvent(event), oldObj)
+}
+
+func (a *EventListenerAdapter[T]) OnEvict(event *hazelcast.EntryNotified, oldObj *T) {
+	a.listener.OnDelete(NewEvent(event), oldObj)
+}
+
+func (a *EventListenerAdapter[T]) OnExpire(event *hazelcast.EntryNotified, oldObj *T) {
+	a.listener.OnDelete(NewEvent(event), oldObj)
+}
+
+func (a *EventListenerAdapter[T]) OnClear(event *hazelcast.EntryNotified) {
+	a.listener.OnClear(NewEvent(event))
+}
+
+func (a *EventListenerAdapter[T]) OnError(event *hazelcast.EntryNotified, err error) {
+	a.listener.OnError(NewEvent(event), err)
+}
@@ -6,88 +6,178 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/hzerrors"
 	"github.com/hazelcast/hazelcast-go-client/predicate"
-	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
 )
 
 type HazelcastCache[T any] struct {
-	ctx    context.Context
-	client *hazelcast.Client
+	ctx         context.Context
+	client      *hazelcast.Client
+	codec       Codec[T]
+	opts        options[T]
+	clusterName string
+	lifecycle   *lifecycleTracker
 }
 
 type HazelcastBasedCache[T any] interface {
-	Cache[T]
-	GetQuery(mapName string, query predicate.Predicate) ([]T, error)
+	QueryableCache[T]
+	ConcurrentCache[T]
+	ProcessingCache[T]
 	GetClient() *hazelcast.Client
 	GetMap(mapKey string) (*hazelcast.Map, error)
-	AddListener(mapName string, listener Listener[T]) error
+	Health() Health
+	AddLifecycleListener(listener LifecycleListener) types.UUID
+	RemoveLifecycleListener(id types.UUID)
+	Close(ctx context.Context) error
 }
 
-func NewHazelcastCache[T any](config hazelcast.Config) (*HazelcastCache[T], error) {
+func NewHazelcastCache[T any](config hazelcast.Config, opts ...Option[T]) (*HazelcastCache[T], error) {
 	ctx := context.Background()
 
+	config = config.Clone()
+	lifecycle := newLifecycleTracker()
+	lifecycle.register(&config)
+
 	client, err := hazelcast.StartNewClientWithConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return &HazelcastCache[T]{ctx: ctx, client: client}, nil
+	lifecycle.initialize(client)
+	clusterName := config.Cluster.Name
+	if clusterName == "" {
+		clusterName = defaultClusterName
+	}
+
+	return newHazelcastCache(client, clusterName, lifecycle, opts), nil
 }
 
-func NewHazelcastCacheWithClient[T any](client *hazelcast.Client) *HazelcastCache[T] {
-	ctx := context.Background()
-	return &HazelcastCache[T]{ctx, client}
+// WithClusterName sets the cluster name reported by Health for caches created using NewHazelcastCacheWithClient.
+// NewHazelcastCache takes the name from the configuration instead.
+func WithClusterName[T any](name string) Option[T] {
+	return func(o *options[T]) {
+		o.clusterName = name
+	}
+}
+
+func NewHazelcastCacheWithClient[T any](client *hazelcast.Client, opts ...Option[T]) *HazelcastCache[T] {
+	lifecycle := newLifecycleTracker()
+	lifecycle.attach(client)
+	return newHazelcastCache(client, "", lifecycle, opts)
+}
+
+func newHazelcastCache[T any](
+	client *hazelcast.Client,
+	clusterName string,
+	lifecycle *lifecycleTracker,
+	opts []Option[T],
+) *HazelcastCache[T] {
+	o := newOptions(opts)
+	if clusterName == "" {
+		clusterName = o.clusterName
+	}
+
+	return &HazelcastCache[T]{
+		ctx:         context.Background(),
+		client:      client,
+		codec:       o.codec,
+		opts:        o,
+		clusterName: clusterName,
+		lifecycle:   lifecycle,
+	}
 }
 
 func (c *HazelcastCache[T]) Put(mapName string, key string, value T) error {
-	mp, err := c.client.GetMap(c.ctx, mapName)
+	return c.PutCtx(c.ctx, mapName, key, value)
+}
+
+func (c *HazelcastCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	mp, encoded, err := c.prepareWrite(ctx, mapName, value)
 	if err != nil {
 		return err
 	}
 
-	bytes, err := json.Marshal(value)
+	return mp.Set(ctx, key, encoded)
+}
+
+func (c *HazelcastCache[T]) PutWithTTL(mapName string, key string, value T, ttl time.Duration) error {
+	return c.PutWithTTLCtx(c.ctx, mapName, key, value, ttl)
+}
+
+func (c *HazelcastCache[T]) PutWithTTLCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration) error {
+	mp, encoded, err := c.prepareWrite(ctx, mapName, value)
 	if err != nil {
 		return err
 	}
 
-	return mp.Set(c.ctx, key, serialization.JSON(bytes))
+	return mp.SetWithTTL(ctx, key, encoded, ttl)
+}
+
+func (c *HazelcastCache[T]) PutWithTTLAndMaxIdle(mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error {
+	return c.PutWithTTLAndMaxIdleCtx(c.ctx, mapName, key, value, ttl, maxIdle)
+}
+
+func (c *HazelcastCache[T]) PutWithTTLAndMaxIdleCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	value T,
+	ttl time.Duration,
+	maxIdle time.Duration,
+) error {
+	mp, encoded, err := c.prepareWrite(ctx, mapName, value)
+	if err != nil {
+		return err
+	}
+
+	return mp.SetWithTTLAndMaxIdle(ctx, key, encoded, ttl, maxIdle)
 }
 
 func (c *HazelcastCache[T]) Get(mapName string, key string) (*T, error) {
-	mp, err := c.client.GetMap(c.ctx, mapName)
+	return c.GetCtx(c.ctx, mapName, key)
+}
+
+func (c *HazelcastCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
 	if err != nil {
 		return nil, err
 	}
 
-	value, err := mp.Get(c.ctx, key)
+	value, err := mp.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
 
 	if value == nil {
-		//nolint:nilnil // Cache miss is a valid state, not an error; also comply with api
-		return nil, nil
+		return c.opts.miss()
 	}
 
-	unmarshalledValue := new(T)
-	if err := c.unmarshalHazelcastJson(key, value, unmarshalledValue); err != nil {
+	decodedValue, err := decode(c.codec, mapName, key, value)
+	if err != nil {
 		return nil, err
 	}
 
-	return unmarshalledValue, nil
+	return &decodedValue, nil
 }
 
 func (c *HazelcastCache[T]) Delete(mapName string, key string) error {
-	mp, err := c.client.GetMap(c.ctx, mapName)
+	return c.DeleteCtx(c.ctx, mapName, key)
+}
+
+func (c *HazelcastCache[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	mp, err := c.client.GetMap(ctx, mapName)
 	if err != nil {
 		return err
 	}
 
-	err = mp.Delete(c.ctx, key)
+	err = mp.Delete(ctx, key)
 	if err != nil {
 		return err
 	}
@@ -95,33 +185,188 @@ func (c *HazelcastCache[T]) Delete(mapName string, key string) error {
 	return nil
 }
 
+func (c *HazelcastCache[T]) PutAll(mapName string, values map[string]T) error {
+	return c.PutAllCtx(c.ctx, mapName, values)
+}
+
+func (c *HazelcastCache[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]types.Entry, 0, len(values))
+	for key, value := range values {
+		encoded, err := c.codec.Encode(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value with key '%s': %w", key, err)
+		}
+
+		entries = append(entries, types.NewEntry(key, encoded))
+	}
+
+	return mp.PutAll(ctx, entries...)
+}
+
+func (c *HazelcastCache[T]) GetAll(mapName string, keys []string) (map[string]T, error) {
+	return c.GetAllCtx(c.ctx, mapName, keys)
+}
+
+func (c *HazelcastCache[T]) GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := mp.GetAll(ctx, toAnySlice(keys)...)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]T, len(entries))
+	errs := make(map[string]error)
+	for _, entry := range entries {
+		key, err := stringKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := decode(c.codec, mapName, key, entry.Value)
+		if c.opts.skipDecodeError(err) {
+			continue
+		} else if err != nil {
+			errs[key] = err
+			continue
+		}
+
+		values[key] = value
+	}
+
+	if len(errs) > 0 {
+		return values, &BatchError{Errors: errs}
+	}
+
+	return values, nil
+}
+
+func (c *HazelcastCache[T]) DeleteAll(mapName string, keys []string) error {
+	return c.DeleteAllCtx(c.ctx, mapName, keys)
+}
+
+func (c *HazelcastCache[T]) DeleteAllCtx(ctx context.Context, mapName string, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	return mp.RemoveAll(ctx, predicate.In(keyAttribute, toAnySlice(keys)...))
+}
+
 func (c *HazelcastCache[T]) GetQuery(mapName string, query predicate.Predicate) ([]T, error) {
-	mp, err := c.client.GetMap(c.ctx, mapName)
+	return c.GetQueryCtx(c.ctx, mapName, query)
+}
+
+func (c *HazelcastCache[T]) GetQueryCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]T, error) {
+	entries, err := c.GetQueryEntriesCtx(ctx, mapName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return entryValues(entries), nil
+}
+
+func (c *HazelcastCache[T]) GetQueryEntries(mapName string, query predicate.Predicate) ([]Entry[T], error) {
+	return c.GetQueryEntriesCtx(c.ctx, mapName, query)
+}
+
+func (c *HazelcastCache[T]) GetQueryEntriesCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]Entry[T], error) {
+	mp, err := c.client.GetMap(ctx, mapName)
 	if err != nil {
 		return nil, err
 	}
 
-	entries, err := mp.GetEntrySetWithPredicate(c.ctx, query)
+	entries, err := mp.GetEntrySetWithPredicate(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 
-	unmarshalledValues := make([]T, 0)
+	unmarshalledEntries := make([]Entry[T], 0, len(entries))
 	for _, entry := range entries {
-		hzJsonValue, ok := entry.Value.(serialization.JSON)
-		if !ok {
-			return nil, fmt.Errorf("value of cached object with key '%s' is not a HazelcastJsonValue", entry.Key.(string))
+		key, err := stringKey(entry.Key)
+		if err != nil {
+			return nil, err
 		}
 
-		var unmarshalledValue T
-		if err := json.Unmarshal(hzJsonValue, &unmarshalledValue); err != nil {
+		value, err := decode(c.codec, mapName, key, entry.Value)
+		if c.opts.skipDecodeError(err) {
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 
-		unmarshalledValues = append(unmarshalledValues, unmarshalledValue)
+		unmarshalledEntries = append(unmarshalledEntries, Entry[T]{Key: key, Value: value})
 	}
 
-	return unmarshalledValues, nil
+	return unmarshalledEntries, nil
+}
+
+func (c *HazelcastCache[T]) GetQueryKeys(mapName string, query predicate.Predicate) ([]string, error) {
+	return c.GetQueryKeysCtx(c.ctx, mapName, query)
+}
+
+func (c *HazelcastCache[T]) GetQueryKeysCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]string, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := mp.GetKeySetWithPredicate(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	stringKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		converted, err := stringKey(key)
+		if err != nil {
+			return nil, err
+		}
+		stringKeys = append(stringKeys, converted)
+	}
+
+	return stringKeys, nil
+}
+
+func (c *HazelcastCache[T]) GetQueryPage(mapName string, query predicate.Predicate, page int, pageSize int) (*Page[T], error) {
+	return c.GetQueryPageCtx(c.ctx, mapName, query, page, pageSize)
+}
+
+func (c *HazelcastCache[T]) GetQueryPageCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	page int,
+	pageSize int,
+) (*Page[T], error) {
+	return queryPage[T](ctx, c, mapName, query, page, pageSize)
+}
+
+func (c *HazelcastCache[T]) QueryIter(mapName string, query predicate.Predicate) iter.Seq2[string, T] {
+	seq, _ := c.QueryIterCtx(c.ctx, mapName, query, DefaultQueryPageSize)
+	return seq
+}
+
+func (c *HazelcastCache[T]) QueryIterCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	pageSize int,
+) (iter.Seq2[string, T], func() error) {
+	return queryIter[T](ctx, c, mapName, query, pageSize)
 }
 
 func (c *HazelcastCache[T]) GetClient() *hazelcast.Client {
@@ -132,53 +377,388 @@ func (c *HazelcastCache[T]) GetMap(mapName string) (*hazelcast.Map, error) {
 	return c.client.GetMap(c.ctx, mapName)
 }
 
-func (c *HazelcastCache[T]) AddListener(mapName string, listener Listener[T]) error {
-	mp, err := c.client.GetMap(c.ctx, mapName)
+// Health reports whether the client is connected to the cluster and how many members the cluster has.
+func (c *HazelcastCache[T]) Health() Health {
+	health := c.lifecycle.health()
+	health.ClusterName = c.clusterName
+	return health
+}
+
+// AddLifecycleListener registers a listener that is notified when the client connects, disconnects or shuts down.
+func (c *HazelcastCache[T]) AddLifecycleListener(listener LifecycleListener) types.UUID {
+	return c.lifecycle.add(listener)
+}
+
+func (c *HazelcastCache[T]) RemoveLifecycleListener(id types.UUID) {
+	c.lifecycle.remove(id)
+}
+
+// Close shuts down the underlying client, which also affects other caches sharing it. Closing a cache twice is a no-op.
+func (c *HazelcastCache[T]) Close(ctx context.Context) error {
+	return c.client.Shutdown(ctx)
+}
+
+func (c *HazelcastCache[T]) AddListener(mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error) {
+	return c.AddListenerCtx(c.ctx, mapName, listener, opts...)
+}
+
+func (c *HazelcastCache[T]) AddListenerCtx(
+	ctx context.Context,
+	mapName string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	register := func(mp *hazelcast.Map, mapListener hazelcast.MapListener, includeValue bool) (types.UUID, error) {
+		return mp.AddListener(ctx, mapListener, includeValue)
+	}
+
+	return c.addListener(ctx, mapName, listener, opts, register)
+}
+
+func (c *HazelcastCache[T]) AddListenerForKey(
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.AddListenerForKeyCtx(c.ctx, mapName, key, listener, opts...)
+}
+
+func (c *HazelcastCache[T]) AddListenerForKeyCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	register := func(mp *hazelcast.Map, mapListener hazelcast.MapListener, includeValue bool) (types.UUID, error) {
+		return mp.AddListenerWithKey(ctx, mapListener, key, includeValue)
+	}
+
+	return c.addListener(ctx, mapName, listener, opts, register)
+}
+
+func (c *HazelcastCache[T]) AddListenerWithPredicate(
+	mapName string,
+	query predicate.Predicate,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.AddListenerWithPredicateCtx(c.ctx, mapName, query, listener, opts...)
+}
+
+func (c *HazelcastCache[T]) AddListenerWithPredicateCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	register := func(mp *hazelcast.Map, mapListener hazelcast.MapListener, includeValue bool) (types.UUID, error) {
+		return mp.AddListenerWithPredicate(ctx, mapListener, query, includeValue)
+	}
+
+	return c.addListener(ctx, mapName, listener, opts, register)
+}
+
+func (c *HazelcastCache[T]) RemoveListener(handle ListenerHandle) error {
+	return c.RemoveListenerCtx(c.ctx, handle)
+}
+
+func (c *HazelcastCache[T]) RemoveListenerCtx(ctx context.Context, handle ListenerHandle) error {
+	mp, err := c.client.GetMap(ctx, handle.MapName)
 	if err != nil {
 		return err
 	}
 
-	// Add a listener to the map to react to events.
-	_, err = mp.AddListener(c.ctx, hazelcast.MapListener{
-		EntryAdded: func(event *hazelcast.EntryNotified) {
-			var obj T
-			if err := c.unmarshalHazelcastJson(event.Key, event.Value, &obj); err != nil {
-				listener.OnError(event, err)
-				return
-			}
-
-			listener.OnAdd(event, obj)
-		},
-		EntryUpdated: func(event *hazelcast.EntryNotified) {
-			var obj T
-			if err := c.unmarshalHazelcastJson(event.Key, event.Value, &obj); err != nil {
-				listener.OnError(event, err)
-				return
-			}
-
-			var oldObj T
-			if err := c.unmarshalHazelcastJson(event.Key, event.OldValue, &oldObj); err != nil {
-				listener.OnError(event, err)
-				return
-			}
-
-			listener.OnUpdate(event, obj, oldObj)
-		},
-		EntryRemoved: func(event *hazelcast.EntryNotified) {
-			listener.OnDelete(event)
-		},
-	}, true)
-	if err != nil {
-		return fmt.Errorf("failed to add listener: %w", err)
+	if err := mp.RemoveListener(ctx, handle.uuid); err != nil {
+		return fmt.Errorf("failed to remove listener: %w", err)
 	}
 
 	return nil
 }
 
-func (c *HazelcastCache[T]) unmarshalHazelcastJson(key any, value any, obj any) error {
-	hzJsonValue, ok := value.(serialization.JSON)
+func (c *HazelcastCache[T]) PutIfAbsent(mapName string, key string, value T) (*T, error) {
+	return c.PutIfAbsentCtx(c.ctx, mapName, key, value)
+}
+
+func (c *HazelcastCache[T]) PutIfAbsentCtx(ctx context.Context, mapName string, key string, value T) (*T, error) {
+	mp, encoded, err := c.prepareWrite(ctx, mapName, value)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := mp.PutIfAbsent(ctx, key, encoded)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+
+	decodedValue, err := decode(c.codec, mapName, key, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decodedValue, nil
+}
+
+func (c *HazelcastCache[T]) Replace(mapName string, key string, oldValue T, newValue T) (bool, error) {
+	return c.ReplaceCtx(c.ctx, mapName, key, oldValue, newValue)
+}
+
+func (c *HazelcastCache[T]) ReplaceCtx(ctx context.Context, mapName string, key string, oldValue T, newValue T) (bool, error) {
+	encodedOld, err := c.codec.Encode(oldValue)
+	if err != nil {
+		return false, err
+	}
+
+	mp, encodedNew, err := c.prepareWrite(ctx, mapName, newValue)
+	if err != nil {
+		return false, err
+	}
+
+	return mp.ReplaceIfSame(ctx, key, encodedOld, encodedNew)
+}
+
+func (c *HazelcastCache[T]) Update(mapName string, key string, fn func(old *T) (T, error)) (T, error) {
+	return c.UpdateCtx(c.ctx, mapName, key, fn)
+}
+
+func (c *HazelcastCache[T]) UpdateCtx(ctx context.Context, mapName string, key string, fn func(old *T) (T, error)) (T, error) {
+	return update(ctx, c, c.codec, mapName, key, fn)
+}
+
+func (c *HazelcastCache[T]) Lock(mapName string, key string, lease time.Duration) error {
+	return c.LockCtx(c.ctx, mapName, key, lease)
+}
+
+func (c *HazelcastCache[T]) LockCtx(ctx context.Context, mapName string, key string, lease time.Duration) error {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	if lease > 0 {
+		return mp.LockWithLease(ctx, key, lease)
+	}
+
+	return mp.Lock(ctx, key)
+}
+
+func (c *HazelcastCache[T]) TryLock(mapName string, key string, timeout time.Duration, lease time.Duration) (bool, error) {
+	return c.TryLockCtx(c.ctx, mapName, key, timeout, lease)
+}
+
+func (c *HazelcastCache[T]) TryLockCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	timeout time.Duration,
+	lease time.Duration,
+) (bool, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return false, err
+	}
+
+	if lease > 0 {
+		return mp.TryLockWithLeaseAndTimeout(ctx, key, lease, timeout)
+	}
+
+	return mp.TryLockWithTimeout(ctx, key, timeout)
+}
+
+func (c *HazelcastCache[T]) Unlock(mapName string, key string) error {
+	return c.UnlockCtx(c.ctx, mapName, key)
+}
+
+func (c *HazelcastCache[T]) UnlockCtx(ctx context.Context, mapName string, key string) error {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	if err := mp.Unlock(ctx, key); err != nil {
+		if errors.Is(err, hzerrors.ErrIllegalMonitorState) {
+			return fmt.Errorf("%w: %w", ErrLockNotHeld, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (c *HazelcastCache[T]) getRaw(ctx context.Context, mapName string, key string) (any, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	return mp.Get(ctx, key)
+}
+
+func (c *HazelcastCache[T]) putIfAbsentRaw(ctx context.Context, mapName string, key string, value any) (bool, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := mp.PutIfAbsent(ctx, key, value)
+	return existing == nil, err
+}
+
+func (c *HazelcastCache[T]) replaceRaw(ctx context.Context, mapName string, key string, oldValue any, newValue any) (bool, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return false, err
+	}
+
+	return mp.ReplaceIfSame(ctx, key, oldValue, newValue)
+}
+
+func (c *HazelcastCache[T]) ExecuteOnKey(mapName string, key string, processor EntryProcessor) (any, error) {
+	return c.ExecuteOnKeyCtx(c.ctx, mapName, key, processor)
+}
+
+func (c *HazelcastCache[T]) ExecuteOnKeyCtx(ctx context.Context, mapName string, key string, processor EntryProcessor) (any, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	return mp.ExecuteOnKey(ctx, processor, key)
+}
+
+func (c *HazelcastCache[T]) ExecuteOnKeys(mapName string, keys []string, processor EntryProcessor) (map[string]any, error) {
+	return c.ExecuteOnKeysCtx(c.ctx, mapName, keys, processor)
+}
+
+// ExecuteOnKeysCtx executes the processor using a key predicate, since the Hazelcast client drops the keys
+// of the results when executing a processor on a set of keys.
+func (c *HazelcastCache[T]) ExecuteOnKeysCtx(
+	ctx context.Context,
+	mapName string,
+	keys []string,
+	processor EntryProcessor,
+) (map[string]any, error) {
+	if len(keys) == 0 {
+		return map[string]any{}, nil
+	}
+
+	return c.ExecuteOnEntriesCtx(ctx, mapName, predicate.In(keyAttribute, toAnySlice(keys)...), processor)
+}
+
+func (c *HazelcastCache[T]) ExecuteOnEntries(mapName string, query predicate.Predicate, processor EntryProcessor) (map[string]any, error) {
+	return c.ExecuteOnEntriesCtx(c.ctx, mapName, query, processor)
+}
+
+func (c *HazelcastCache[T]) ExecuteOnEntriesCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	processor EntryProcessor,
+) (map[string]any, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := mp.ExecuteOnEntriesWithPredicate(ctx, processor, query)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]any, len(entries))
+	for _, entry := range entries {
+		key, err := stringKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		results[key] = entry.Value
+	}
+
+	return results, nil
+}
+
+// addListener resolves the map and registers the listener using the given registration function of the map.
+func (c *HazelcastCache[T]) addListener(
+	ctx context.Context,
+	mapName string,
+	listener Listener[T],
+	opts []ListenerOption,
+	register func(mp *hazelcast.Map, mapListener hazelcast.MapListener, includeValue bool) (types.UUID, error),
+) (ListenerHandle, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return ListenerHandle{}, err
+	}
+
+	o := newListenerOptions(opts)
+	dispatch := func(event *hazelcast.EntryNotified) {
+		dispatchEvent(event, listener, c.codec, o.includeValue)
+	}
+
+	mapListener := hazelcast.MapListener{
+		EntryAdded:   dispatch,
+		EntryUpdated: dispatch,
+		EntryRemoved: dispatch,
+	}
+
+	// Only subscribe to evictions, expiries and map events if the listener can handle them.
+	if _, ok := listener.(ExtendedListener[T]); ok {
+		mapListener.EntryEvicted = dispatch
+		mapListener.EntryExpired = dispatch
+		mapListener.MapCleared = dispatch
+		mapListener.MapEvicted = dispatch
+	}
+
+	// Add a listener to the map to react to events.
+	uuid, err := register(mp, mapListener, o.includeValue)
+	if err != nil {
+		return ListenerHandle{}, fmt.Errorf("failed to add listener: %w", err)
+	}
+
+	return newListenerHandle(mapName, uuid), nil
+}
+
+// prepareWrite resolves the map and encodes the value that should be written to it.
+func (c *HazelcastCache[T]) prepareWrite(ctx context.Context, mapName string, value T) (*hazelcast.Map, any, error) {
+	mp, err := c.client.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoded, err := c.codec.Encode(value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mp, encoded, nil
+}
+
+// stringKey returns the key of a cached object, which has to be a string since all keys are written as strings.
+func stringKey(key any) (string, error) {
+	stringKey, ok := key.(string)
 	if !ok {
-		return fmt.Errorf("value of cached object with key '%s' is not a HazelcastJsonValue", key)
+		return "", fmt.Errorf("key '%v' of cached object is not a string", key)
+	}
+	return stringKey, nil
+}
+
+func toAnySlice(keys []string) []any {
+	converted := make([]any, len(keys))
+	for i, key := range keys {
+		converted[i] = key
+	}
+	return converted
+}
+
+func toStringSlice(keys []any) []string {
+	converted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if stringKey, ok := key.(string); ok {
+			converted = append(converted, stringKey)
+		}
 	}
-	return json.Unmarshal(hzJsonValue, obj)
+	return converted
 }
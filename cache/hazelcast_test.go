@@ -5,13 +5,17 @@
 package cache
 
 import (
+	"bytes"
+	"context"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/hazelcast/hazelcast-go-client/cluster"
 	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
 	"github.com/stretchr/testify/assert"
 	"github.com/telekom/pubsub-horizon-go/test"
 )
@@ -45,8 +49,56 @@ func TestNewCache(t *testing.T) {
 
 func TestNewCacheWithClient(t *testing.T) {
 	assertions := assert.New(t)
-	cacheWithSameClient := NewHazelcastCacheWithClient[TestDummy](cache.client)
+	cacheWithSameClient := NewHazelcastCacheWithClient[TestDummy](cache.client, WithClusterName[TestDummy]("horizon"))
 	assertions.Equal(cache.client, cacheWithSameClient.client)
+
+	health := cacheWithSameClient.Health()
+	assertions.True(health.Connected)
+	assertions.Equal("horizon", health.ClusterName)
+}
+
+func TestCache_Health(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.Eventually(func() bool {
+		return cache.Health().Members == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	health := cache.Health()
+	assertions.True(health.Connected)
+	assertions.Equal("horizon", health.ClusterName)
+	assertions.False(health.LastStateChange.IsZero())
+}
+
+func TestCache_Close(t *testing.T) {
+	assertions := assert.New(t)
+
+	config := hazelcast.Config{}
+	config.Cluster.Name = "horizon"
+	config.Cluster.Network.SetAddresses(test.GetHazelcastHost())
+
+	closableCache, err := NewHazelcastCache[TestDummy](config)
+	assertions.NoError(err)
+
+	var mu sync.Mutex
+	var states []hazelcast.LifecycleState
+	closableCache.AddLifecycleListener(func(event LifecycleEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		states = append(states, event.State)
+	})
+
+	assertions.NoError(closableCache.Close(context.Background()))
+	assertions.NoError(closableCache.Close(context.Background()))
+
+	assertions.Eventually(func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(states) > 0 && states[len(states)-1] == hazelcast.LifecycleStateShutDown
+	}, 5*time.Second, 10*time.Millisecond)
+	assertions.False(closableCache.Health().Connected)
+	assertions.Zero(closableCache.Health().Members)
+	assertions.True(cache.Health().Connected)
 }
 
 func TestCache_Put(t *testing.T) {
@@ -75,6 +127,209 @@ func TestCache_GetQuery(t *testing.T) {
 	assertions.Equal("bar", results[0].Foo)
 }
 
+func TestCache_GetQueryEntries(t *testing.T) {
+	assertions := assert.New(t)
+	query := predicate.Equal("foo", "bar")
+
+	entries, err := cache.GetQueryEntries("testMap", query)
+	assertions.NoError(err)
+	assertions.Equal([]Entry[TestDummy]{{Key: "dummy", Value: TestDummy{Foo: "bar"}}}, entries)
+
+	keys, err := cache.GetQueryKeys("testMap", query)
+	assertions.NoError(err)
+	assertions.Equal([]string{"dummy"}, keys)
+}
+
+func TestCache_QueryPaging(t *testing.T) {
+	assertions := assert.New(t)
+
+	values := make(map[string]TestDummy)
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		values[key] = TestDummy{Foo: "paged"}
+	}
+	assertions.NoError(cache.PutAll("pagedMap", values))
+
+	page, err := cache.GetQueryPage("pagedMap", predicate.Equal("foo", "paged"), 1, 2)
+	assertions.NoError(err)
+	assertions.Equal(5, page.TotalCount)
+	assertions.True(page.HasNext())
+	assertions.Equal([]Entry[TestDummy]{{Key: "c", Value: TestDummy{Foo: "paged"}}, {Key: "d", Value: TestDummy{Foo: "paged"}}}, page.Entries)
+
+	seq, errFn := cache.QueryIterCtx(context.Background(), "pagedMap", predicate.Equal("foo", "paged"), 2)
+	keys := make([]string, 0)
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	assertions.NoError(errFn())
+	assertions.Equal([]string{"a", "b", "c", "d", "e"}, keys)
+}
+
+func TestCache_Ctx(t *testing.T) {
+	assertions := assert.New(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assertions.NoError(cache.PutCtx(ctx, "testMap", "ctxDummy", TestDummy{Foo: "ctx"}))
+
+	dummy, err := cache.GetCtx(ctx, "testMap", "ctxDummy")
+	assertions.NoError(err)
+	assertions.Equal("ctx", dummy.Foo)
+
+	results, err := cache.GetQueryCtx(ctx, "testMap", predicate.Equal("foo", "ctx"))
+	assertions.NoError(err)
+	assertions.Len(results, 1)
+
+	assertions.NoError(cache.DeleteCtx(ctx, "testMap", "ctxDummy"))
+
+	canceledCtx, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	_, err = cache.GetCtx(canceledCtx, "testMap", "ctxDummy")
+	assertions.Error(err)
+}
+
+func TestCache_PutWithTTL(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.NoError(cache.PutWithTTL("testMap", "ttlDummy", TestDummy{Foo: "ttl"}, time.Second))
+	assertions.NoError(cache.PutWithTTLAndMaxIdle("testMap", "idleDummy", TestDummy{Foo: "idle"}, 0, time.Second))
+
+	dummy, err := cache.Get("testMap", "ttlDummy")
+	assertions.NoError(err)
+	assertions.NotNil(dummy)
+
+	assertions.Eventually(func() bool {
+		ttlDummy, err := cache.Get("testMap", "ttlDummy")
+		return err == nil && ttlDummy == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	// The idle entry has not been accessed since it was written, so it must have expired as well.
+	idleDummy, err := cache.Get("testMap", "idleDummy")
+	assertions.NoError(err)
+	assertions.Nil(idleDummy)
+}
+
+func TestCache_BatchOperations(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.NoError(cache.PutAll("batchMap", map[string]TestDummy{
+		"a": {Foo: "bar"},
+		"b": {Foo: "baz"},
+	}))
+
+	values, err := cache.GetAll("batchMap", []string{"a", "b", "missing"})
+	assertions.NoError(err)
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}, "b": {Foo: "baz"}}, values)
+
+	mp, err := cache.GetMap("batchMap")
+	assertions.NoError(err)
+	assertions.NoError(mp.Set(context.Background(), "corrupt", serialization.JSON(`{"foo": 1}`)))
+
+	values, err = cache.GetAll("batchMap", []string{"a", "corrupt"})
+	var batchErr *BatchError
+	assertions.ErrorAs(err, &batchErr)
+	assertions.Contains(batchErr.Errors, "corrupt")
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}}, values)
+
+	assertions.NoError(cache.DeleteAll("batchMap", []string{"a", "b", "corrupt"}))
+
+	values, err = cache.GetAll("batchMap", []string{"a", "b", "corrupt"})
+	assertions.NoError(err)
+	assertions.Empty(values)
+}
+
+func TestCache_NonStringKeys(t *testing.T) {
+	assertions := assert.New(t)
+
+	mp, err := cache.GetMap("nonStringKeyMap")
+	assertions.NoError(err)
+	assertions.NoError(mp.Set(context.Background(), int64(1), serialization.JSON(`{"foo": "bar"}`)))
+
+	_, err = cache.GetQueryKeys("nonStringKeyMap", predicate.True())
+	assertions.ErrorContains(err, "key '1' of cached object is not a string")
+
+	_, err = cache.GetQueryEntries("nonStringKeyMap", predicate.True())
+	assertions.ErrorContains(err, "key '1' of cached object is not a string")
+}
+
+func TestCache_Codec(t *testing.T) {
+	assertions := assert.New(t)
+	bytesCache := NewHazelcastCacheWithClient[[]byte](cache.client, WithCodec[[]byte](BytesCodec{}))
+
+	assertions.NoError(bytesCache.Put("testBytesMap", "raw", []byte("not json")))
+
+	value, err := bytesCache.Get("testBytesMap", "raw")
+	assertions.NoError(err)
+	assertions.Equal([]byte("not json"), *value)
+
+	_, err = cache.Get("testBytesMap", "raw")
+	assertions.ErrorContains(err, "could not decode cached object with key 'raw'")
+	assertions.ErrorIs(err, ErrDecode)
+	assertions.ErrorIs(err, ErrNotJSON)
+
+	var skipped []string
+	reportingCache := NewHazelcastCacheWithClient[TestDummy](cache.client, WithDecodeErrorHandler[TestDummy](func(err *DecodeError) {
+		skipped = append(skipped, err.Key)
+	}))
+
+	values, err := reportingCache.GetQuery("testBytesMap", predicate.True())
+	assertions.NoError(err)
+	assertions.Empty(values)
+	assertions.Equal([]string{"raw"}, skipped)
+
+	page, err := reportingCache.GetQueryPage("testBytesMap", predicate.True(), 0, 10)
+	assertions.NoError(err)
+	assertions.Empty(page.Entries)
+	assertions.Equal([]string{"raw", "raw"}, skipped)
+
+	notFoundCache := NewHazelcastCacheWithClient[TestDummy](cache.client, WithNotFoundError[TestDummy]())
+	_, err = notFoundCache.Get("testBytesMap", "missing")
+	assertions.ErrorIs(err, ErrNotFound)
+}
+
+func TestCache_ConditionalWrites(t *testing.T) {
+	assertions := assert.New(t)
+
+	existing, err := cache.PutIfAbsent("testConcurrentMap", "dummy", TestDummy{Foo: "bar"})
+	assertions.NoError(err)
+	assertions.Nil(existing)
+
+	existing, err = cache.PutIfAbsent("testConcurrentMap", "dummy", TestDummy{Foo: "baz"})
+	assertions.NoError(err)
+	assertions.Equal(&TestDummy{Foo: "bar"}, existing)
+
+	replaced, err := cache.Replace("testConcurrentMap", "dummy", TestDummy{Foo: "bar"}, TestDummy{Foo: "fizz"})
+	assertions.NoError(err)
+	assertions.True(replaced)
+
+	updated, err := cache.Update("testConcurrentMap", "dummy", func(old *TestDummy) (TestDummy, error) {
+		return TestDummy{Foo: old.Foo + "buzz"}, nil
+	})
+	assertions.NoError(err)
+	assertions.Equal("fizzbuzz", updated.Foo)
+}
+
+func TestCache_Lock(t *testing.T) {
+	assertions := assert.New(t)
+
+	owner := NewLockContext(context.Background())
+	other := NewLockContext(context.Background())
+
+	assertions.NoError(cache.LockCtx(owner, "testConcurrentMap", "dummy", 0))
+
+	locked, err := cache.TryLockCtx(other, "testConcurrentMap", "dummy", 10*time.Millisecond, 0)
+	assertions.NoError(err)
+	assertions.False(locked)
+	assertions.ErrorIs(cache.UnlockCtx(other, "testConcurrentMap", "dummy"), ErrLockNotHeld)
+
+	assertions.NoError(cache.UnlockCtx(owner, "testConcurrentMap", "dummy"))
+
+	locked, err = cache.TryLockCtx(other, "testConcurrentMap", "dummy", time.Second, time.Second)
+	assertions.NoError(err)
+	assertions.True(locked)
+	assertions.NoError(cache.UnlockCtx(other, "testConcurrentMap", "dummy"))
+}
+
 func TestCache_Delete(t *testing.T) {
 	assertions := assert.New(t)
 
@@ -98,7 +353,7 @@ func TestCache_AddListener(t *testing.T) {
 	listener := &MockListener[TestDummy]{}
 	listenerDummy := TestDummy{Foo: "bar"}
 
-	err := cache.AddListener("testMap", listener)
+	handle, err := cache.AddListener("testMap", listener)
 	assertions.NoError(err)
 
 	t.Run("Add", func(t *testing.T) {
@@ -106,7 +361,7 @@ func TestCache_AddListener(t *testing.T) {
 		err := cache.Put("testMap", "listenerDummy", listenerDummy)
 		assertions.NoError(err)
 		assertions.Eventually(func() bool {
-			return listener.onAddCalled
+			return listener.onAddCalled.Load()
 		}, 5*time.Second, 10*time.Millisecond)
 	})
 
@@ -118,7 +373,7 @@ func TestCache_AddListener(t *testing.T) {
 		assertions.NoError(err)
 
 		assertions.Eventually(func() bool {
-			return listener.onUpdateCalled
+			return listener.onUpdateCalled.Load()
 		}, 5*time.Second, 10*time.Millisecond)
 	})
 
@@ -128,12 +383,150 @@ func TestCache_AddListener(t *testing.T) {
 		err := cache.Delete("testMap", "listenerDummy")
 		assertions.NoError(err)
 		assertions.Eventually(func() bool {
-			return listener.onDeleteCalled
+			return listener.onDeleteCalled.Load()
 		}, 5*time.Second, 10*time.Millisecond)
 	})
 
 	t.Run("Errors", func(t *testing.T) {
-		assertions.False(listener.onErrorCalled)
-		assertions.NoError(listener.err)
+		assertions.False(listener.onErrorCalled.Load())
+		assertions.NoError(listener.lastError())
 	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assertions := assert.New(t)
+		assertions.NoError(cache.RemoveListener(handle))
+
+		received := len(listener.receivedKeys())
+		assertions.NoError(cache.Put("testMap", "listenerDummy", listenerDummy))
+		assertions.Never(func() bool {
+			return len(listener.receivedKeys()) > received
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestCache_ExtendedListener(t *testing.T) {
+	assertions := assert.New(t)
+
+	listener := &ExtendedMockListener{}
+	handle, err := cache.AddListener("testExtendedMap", listener)
+	assertions.NoError(err)
+	defer func() {
+		assertions.NoError(cache.RemoveListener(handle))
+	}()
+
+	assertions.NoError(cache.Put("testExtendedMap", "deleted", TestDummy{Foo: "deleted"}))
+	assertions.NoError(cache.Put("testExtendedMap", "evicted", TestDummy{Foo: "evicted"}))
+	assertions.NoError(cache.PutWithTTL("testExtendedMap", "expired", TestDummy{Foo: "expired"}, time.Second))
+	assertions.NoError(cache.Put("testExtendedMap", "cleared", TestDummy{Foo: "cleared"}))
+
+	mp, err := cache.GetMap("testExtendedMap")
+	assertions.NoError(err)
+
+	assertions.NoError(cache.Delete("testExtendedMap", "deleted"))
+	_, err = mp.Evict(context.Background(), "evicted")
+	assertions.NoError(err)
+
+	assertions.Eventually(func() bool {
+		_, _, expired, _ := listener.removals()
+		return expired == 1
+	}, 10*time.Second, 100*time.Millisecond)
+
+	assertions.NoError(mp.Clear(context.Background()))
+
+	assertions.Eventually(func() bool {
+		deleted, evicted, _, cleared := listener.removals()
+		return deleted == 1 && evicted == 1 && cleared == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assertions.Equal(&TestDummy{Foo: "deleted"}, listener.deleted[0])
+	assertions.Equal(&TestDummy{Foo: "evicted"}, listener.evicted[0])
+	assertions.False(listener.onDeleteCalled.Load())
+}
+
+func TestCache_FilteredListeners(t *testing.T) {
+	assertions := assert.New(t)
+
+	keyListener := &MockListener[TestDummy]{}
+	keyHandle, err := cache.AddListenerForKey("testFilteredMap", "a", keyListener)
+	assertions.NoError(err)
+
+	predicateListener := &MockListener[TestDummy]{}
+	predicateHandle, err := cache.AddListenerWithPredicate("testFilteredMap", predicate.Equal("foo", "bar"), predicateListener)
+	assertions.NoError(err)
+
+	keysOnlyListener := &MockListener[TestDummy]{}
+	keysOnlyHandle, err := cache.AddListener("testFilteredMap", keysOnlyListener, WithoutValues())
+	assertions.NoError(err)
+
+	assertions.NoError(cache.Put("testFilteredMap", "a", TestDummy{Foo: "baz"}))
+	assertions.NoError(cache.Put("testFilteredMap", "b", TestDummy{Foo: "bar"}))
+
+	assertions.Eventually(func() bool {
+		return len(keysOnlyListener.receivedKeys()) == 2 && len(keyListener.receivedKeys()) == 1 &&
+			len(predicateListener.receivedKeys()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assertions.Equal([]string{"a"}, keyListener.receivedKeys())
+	assertions.Equal([]string{"b"}, predicateListener.receivedKeys())
+	assertions.Equal(make([]TestDummy, 2), keysOnlyListener.receivedValues())
+
+	for _, handle := range []ListenerHandle{keyHandle, predicateHandle, keysOnlyHandle} {
+		assertions.NoError(cache.RemoveListener(handle))
+	}
+}
+
+func TestHazelcastMultiMap(t *testing.T) {
+	testMultiMap(t, NewHazelcastMultiMap[TestDummy](cache.GetClient()))
+}
+
+func TestHazelcastMultiMap_DecodeErrors(t *testing.T) {
+	testMultiMapDecodeErrors(t,
+		func(opts ...Option[TestDummy]) MultiMap[TestDummy] {
+			return NewHazelcastMultiMap(cache.GetClient(), opts...)
+		},
+		func(_ MultiMap[TestDummy], key string) {
+			mp, err := cache.GetClient().GetMultiMap(context.Background(), "errorMultiMap")
+			assert.NoError(t, err)
+			_, err = mp.Put(context.Background(), key, serialization.JSON(`{"foo": 1}`))
+			assert.NoError(t, err)
+		},
+	)
+}
+
+func TestHazelcastReplicatedMap(t *testing.T) {
+	testReplicatedMap(t, NewHazelcastReplicatedMap[TestDummy](cache.GetClient()))
+}
+
+func TestHazelcastReplicatedMap_Errors(t *testing.T) {
+	testReplicatedMapErrors(t,
+		func(opts ...Option[TestDummy]) ReplicatedMap[TestDummy] {
+			return NewHazelcastReplicatedMap(cache.GetClient(), opts...)
+		},
+		func(_ ReplicatedMap[TestDummy], key string) {
+			mp, err := cache.GetClient().GetReplicatedMap(context.Background(), "errorReplicatedMap")
+			assert.NoError(t, err)
+			_, err = mp.Put(context.Background(), key, serialization.JSON(`{"foo": 1}`))
+			assert.NoError(t, err)
+		},
+	)
+}
+
+func TestCache_Snapshot(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	assertions.NoError(cache.PutAll("testSnapshotMap", map[string]TestDummy{"a": {Foo: "bar"}, "b": {Foo: "baz"}}))
+
+	var buffer bytes.Buffer
+	exported, err := Export(ctx, cache, "testSnapshotMap", &buffer, nil)
+	assertions.NoError(err)
+	assertions.Equal(2, exported)
+
+	result, err := Import[TestDummy](ctx, cache, "testSnapshotCopy", &buffer, ImportOverwrite)
+	assertions.NoError(err)
+	assertions.Equal(ImportResult{Imported: 2}, result)
+
+	values, err := cache.GetAll("testSnapshotCopy", []string{"a", "b"})
+	assertions.NoError(err)
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}, "b": {Foo: "baz"}}, values)
 }
@@ -0,0 +1,310 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/telekom/pubsub-horizon-go/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/telekom/pubsub-horizon-go/cache"
+
+// InstrumentedCache records a span and metrics for every call of the wrapped Cache.
+// If the context carries a tracing.TraceContext, spans are children of its current span.
+// The metrics are recorded with the operation and map name as attributes:
+//   - cache.operation.duration: the duration of the operation in seconds
+//   - cache.operation.errors: the number of failed operations
+//   - cache.hits and cache.misses: the number of keys found and not found by Get and GetAll, including ErrNotFound;
+//     keys whose values could not be decoded count as neither
+//
+// InstrumentedCache only implements Cache, so queries and entry processors have to be executed on the wrapped cache,
+// which records neither spans nor metrics for them.
+type InstrumentedCache[T any] struct {
+	Cache[T]
+
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	hits     metric.Int64Counter
+	misses   metric.Int64Counter
+}
+
+var _ Cache[any] = (*InstrumentedCache[any])(nil)
+
+// InstrumentationOption configures an InstrumentedCache.
+type InstrumentationOption func(*instrumentationOptions)
+
+type instrumentationOptions struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the provider used to create spans instead of the global one.
+func WithTracerProvider(provider trace.TracerProvider) InstrumentationOption {
+	return func(o *instrumentationOptions) {
+		o.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the provider used to record metrics instead of the global one.
+func WithMeterProvider(provider metric.MeterProvider) InstrumentationOption {
+	return func(o *instrumentationOptions) {
+		o.meterProvider = provider
+	}
+}
+
+// NewInstrumentedCache wraps the given cache. It fails if the metric instruments cannot be created.
+func NewInstrumentedCache[T any](cache Cache[T], opts ...InstrumentationOption) (*InstrumentedCache[T], error) {
+	o := instrumentationOptions{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	meter := o.meterProvider.Meter(instrumentationName)
+	c := &InstrumentedCache[T]{Cache: cache, tracer: o.tracerProvider.Tracer(instrumentationName)}
+
+	var err error
+	if c.duration, err = meter.Float64Histogram(
+		"cache.operation.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of cache operations"),
+	); err != nil {
+		return nil, err
+	}
+
+	if c.errors, err = meter.Int64Counter("cache.operation.errors", metric.WithDescription("Number of failed cache operations")); err != nil {
+		return nil, err
+	}
+
+	if c.hits, err = meter.Int64Counter("cache.hits", metric.WithDescription("Number of keys found in the cache")); err != nil {
+		return nil, err
+	}
+
+	if c.misses, err = meter.Int64Counter("cache.misses", metric.WithDescription("Number of keys not found in the cache")); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *InstrumentedCache[T]) Put(mapName string, key string, value T) error {
+	return c.PutCtx(context.Background(), mapName, key, value)
+}
+
+func (c *InstrumentedCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	return c.observe(ctx, "put", mapName, func(ctx context.Context) error {
+		return c.Cache.PutCtx(ctx, mapName, key, value)
+	})
+}
+
+func (c *InstrumentedCache[T]) PutWithTTL(mapName string, key string, value T, ttl time.Duration) error {
+	return c.PutWithTTLCtx(context.Background(), mapName, key, value, ttl)
+}
+
+func (c *InstrumentedCache[T]) PutWithTTLCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration) error {
+	return c.observe(ctx, "put", mapName, func(ctx context.Context) error {
+		return c.Cache.PutWithTTLCtx(ctx, mapName, key, value, ttl)
+	})
+}
+
+func (c *InstrumentedCache[T]) PutWithTTLAndMaxIdle(mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error {
+	return c.PutWithTTLAndMaxIdleCtx(context.Background(), mapName, key, value, ttl, maxIdle)
+}
+
+func (c *InstrumentedCache[T]) PutWithTTLAndMaxIdleCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	value T,
+	ttl time.Duration,
+	maxIdle time.Duration,
+) error {
+	return c.observe(ctx, "put", mapName, func(ctx context.Context) error {
+		return c.Cache.PutWithTTLAndMaxIdleCtx(ctx, mapName, key, value, ttl, maxIdle)
+	})
+}
+
+func (c *InstrumentedCache[T]) Get(mapName string, key string) (*T, error) {
+	return c.GetCtx(context.Background(), mapName, key)
+}
+
+func (c *InstrumentedCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	var value *T
+	var err error
+	observeErr := c.observe(ctx, "get", mapName, func(ctx context.Context) error {
+		value, err = c.Cache.GetCtx(ctx, mapName, key)
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	})
+
+	if observeErr == nil {
+		found := 0
+		if value != nil {
+			found = 1
+		}
+		c.recordLookups(ctx, mapName, found, 1-found)
+	}
+
+	return value, err
+}
+
+func (c *InstrumentedCache[T]) Delete(mapName string, key string) error {
+	return c.DeleteCtx(context.Background(), mapName, key)
+}
+
+func (c *InstrumentedCache[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	return c.observe(ctx, "delete", mapName, func(ctx context.Context) error {
+		return c.Cache.DeleteCtx(ctx, mapName, key)
+	})
+}
+
+func (c *InstrumentedCache[T]) PutAll(mapName string, values map[string]T) error {
+	return c.PutAllCtx(context.Background(), mapName, values)
+}
+
+func (c *InstrumentedCache[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	return c.observe(ctx, "put_all", mapName, func(ctx context.Context) error {
+		return c.Cache.PutAllCtx(ctx, mapName, values)
+	})
+}
+
+func (c *InstrumentedCache[T]) GetAll(mapName string, keys []string) (map[string]T, error) {
+	return c.GetAllCtx(context.Background(), mapName, keys)
+}
+
+func (c *InstrumentedCache[T]) GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	var values map[string]T
+	err := c.observe(ctx, "get_all", mapName, func(ctx context.Context) error {
+		var err error
+		values, err = c.Cache.GetAllCtx(ctx, mapName, keys)
+		return err
+	})
+
+	if values != nil {
+		missing := len(keys) - len(values)
+		var batchErr *BatchError
+		if errors.As(err, &batchErr) {
+			missing -= len(batchErr.Errors)
+		}
+		c.recordLookups(ctx, mapName, len(values), missing)
+	}
+
+	return values, err
+}
+
+func (c *InstrumentedCache[T]) DeleteAll(mapName string, keys []string) error {
+	return c.DeleteAllCtx(context.Background(), mapName, keys)
+}
+
+func (c *InstrumentedCache[T]) DeleteAllCtx(ctx context.Context, mapName string, keys []string) error {
+	return c.observe(ctx, "delete_all", mapName, func(ctx context.Context) error {
+		return c.Cache.DeleteAllCtx(ctx, mapName, keys)
+	})
+}
+
+func (c *InstrumentedCache[T]) AddListener(mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error) {
+	return c.AddListenerCtx(context.Background(), mapName, listener, opts...)
+}
+
+func (c *InstrumentedCache[T]) AddListenerCtx(
+	ctx context.Context,
+	mapName string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	var handle ListenerHandle
+	err := c.observe(ctx, "add_listener", mapName, func(ctx context.Context) error {
+		var err error
+		handle, err = c.Cache.AddListenerCtx(ctx, mapName, listener, opts...)
+		return err
+	})
+	return handle, err
+}
+
+func (c *InstrumentedCache[T]) AddListenerForKey(
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.AddListenerForKeyCtx(context.Background(), mapName, key, listener, opts...)
+}
+
+func (c *InstrumentedCache[T]) AddListenerForKeyCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	var handle ListenerHandle
+	err := c.observe(ctx, "add_listener", mapName, func(ctx context.Context) error {
+		var err error
+		handle, err = c.Cache.AddListenerForKeyCtx(ctx, mapName, key, listener, opts...)
+		return err
+	})
+	return handle, err
+}
+
+func (c *InstrumentedCache[T]) RemoveListener(handle ListenerHandle) error {
+	return c.RemoveListenerCtx(context.Background(), handle)
+}
+
+func (c *InstrumentedCache[T]) RemoveListenerCtx(ctx context.Context, handle ListenerHandle) error {
+	return c.observe(ctx, "remove_listener", handle.MapName, func(ctx context.Context) error {
+		return c.Cache.RemoveListenerCtx(ctx, handle)
+	})
+}
+
+// observe runs the operation within a span and records its duration and failure.
+func (c *InstrumentedCache[T]) observe(ctx context.Context, operation string, mapName string, fn func(ctx context.Context) error) error {
+	attributes := []attribute.KeyValue{
+		attribute.String("cache.operation", operation),
+		attribute.String("cache.map", mapName),
+	}
+
+	parent := ctx
+	if traceCtx := tracing.FromContext(ctx); traceCtx != nil {
+		parent = trace.ContextWithSpan(ctx, trace.SpanFromContext(traceCtx.Context()))
+	}
+
+	spanCtx, span := c.tracer.Start(parent, "cache."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attributes...))
+	defer span.End()
+
+	start := time.Now()
+	err := fn(spanCtx)
+
+	c.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attributes...))
+	if err != nil {
+		c.errors.Add(ctx, 1, metric.WithAttributes(attributes...))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+func (c *InstrumentedCache[T]) recordLookups(ctx context.Context, mapName string, found int, missing int) {
+	attributes := metric.WithAttributes(attribute.String("cache.map", mapName))
+	if found > 0 {
+		c.hits.Add(ctx, int64(found), attributes)
+	}
+	if missing > 0 {
+		c.misses.Add(ctx, int64(missing), attributes)
+	}
+}
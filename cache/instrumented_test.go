@@ -0,0 +1,165 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/pubsub-horizon-go/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentedCache(t *testing.T) {
+	assertions := assert.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder))
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracerProvider)
+	defer otel.SetTracerProvider(previousProvider)
+
+	meterProvider := &recordingMeterProvider{meter: &recordingMeter{values: make(map[string]int64)}}
+
+	instrumentedCache, err := NewInstrumentedCache[TestDummy](
+		NewMemoryCache[TestDummy](),
+		WithTracerProvider(tracerProvider),
+		WithMeterProvider(meterProvider),
+	)
+	assertions.NoError(err)
+
+	traceCtx := tracing.NewTraceContext(context.Background(), "test", false)
+	traceCtx.StartSpan("parent")
+	ctx := tracing.WithTraceContext(context.Background(), traceCtx)
+
+	assertions.NoError(instrumentedCache.PutCtx(ctx, "testMap", "a", TestDummy{Foo: "bar"}))
+
+	_, err = instrumentedCache.Get("testMap", "a")
+	assertions.NoError(err)
+
+	_, err = instrumentedCache.GetAll("testMap", []string{"a", "b", "c"})
+	assertions.NoError(err)
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assertions.Error(instrumentedCache.DeleteCtx(canceledCtx, "testMap", "a"))
+
+	spans := recorder.Ended()
+	assertions.Len(spans, 4)
+	assertions.Equal("cache.put", spans[0].Name())
+	assertions.Equal(traceCtx.CurrentSpan().SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assertions.Contains(spans[0].Attributes(), attribute.String("cache.map", "testMap"))
+	assertions.Equal(codes.Error, spans[3].Status().Code)
+
+	meter := meterProvider.meter
+	assertions.Equal(int64(4), meter.value("cache.operation.duration"))
+	assertions.Equal(int64(1), meter.value("cache.operation.errors"))
+	assertions.Equal(int64(2), meter.value("cache.hits"))
+	assertions.Equal(int64(2), meter.value("cache.misses"))
+
+	t.Run("not found", func(t *testing.T) {
+		recorder := tracetest.NewSpanRecorder()
+		meterProvider := &recordingMeterProvider{meter: &recordingMeter{values: make(map[string]int64)}}
+
+		notFoundCache, err := NewInstrumentedCache[TestDummy](
+			NewMemoryCache[TestDummy](WithNotFoundError[TestDummy]()),
+			WithTracerProvider(tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder))),
+			WithMeterProvider(meterProvider),
+		)
+		assertions.NoError(err)
+
+		_, err = notFoundCache.Get("testMap", "missing")
+		assertions.ErrorIs(err, ErrNotFound)
+
+		assertions.Len(recorder.Ended(), 1)
+		assertions.NotEqual(codes.Error, recorder.Ended()[0].Status().Code)
+		assertions.Equal(int64(0), meterProvider.meter.value("cache.operation.errors"))
+		assertions.Equal(int64(1), meterProvider.meter.value("cache.misses"))
+	})
+
+	t.Run("decode errors", func(t *testing.T) {
+		meterProvider := &recordingMeterProvider{meter: &recordingMeter{values: make(map[string]int64)}}
+		memoryCache := NewMemoryCache[TestDummy]()
+		defer memoryCache.Close()
+
+		corruptCache, err := NewInstrumentedCache[TestDummy](memoryCache, WithMeterProvider(meterProvider))
+		assertions.NoError(err)
+
+		assertions.NoError(corruptCache.Put("testMap", "a", TestDummy{Foo: "bar"}))
+		memoryCache.maps["testMap"]["corrupt"] = &memoryEntry{value: serialization.JSON(`{"foo": 1}`)}
+
+		_, err = corruptCache.GetAll("testMap", []string{"a", "corrupt", "missing"})
+		var batchErr *BatchError
+		assertions.ErrorAs(err, &batchErr)
+		assertions.Equal(int64(1), meterProvider.meter.value("cache.hits"))
+		assertions.Equal(int64(1), meterProvider.meter.value("cache.misses"))
+	})
+}
+
+// recordingMeterProvider provides a meter that counts the recorded measurements per instrument.
+type recordingMeterProvider struct {
+	noop.MeterProvider
+	meter *recordingMeter
+}
+
+func (p *recordingMeterProvider) Meter(_ string, _ ...metric.MeterOption) metric.Meter {
+	return p.meter
+}
+
+type recordingMeter struct {
+	noop.Meter
+	mu     sync.Mutex
+	values map[string]int64
+}
+
+func (m *recordingMeter) Int64Counter(name string, _ ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	return &recordingCounter{meter: m, name: name}, nil
+}
+
+func (m *recordingMeter) Float64Histogram(name string, _ ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return &recordingHistogram{meter: m, name: name}, nil
+}
+
+func (m *recordingMeter) add(name string, value int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[name] += value
+}
+
+func (m *recordingMeter) value(name string) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.values[name]
+}
+
+type recordingCounter struct {
+	noop.Int64Counter
+	meter *recordingMeter
+	name  string
+}
+
+func (c *recordingCounter) Add(_ context.Context, value int64, _ ...metric.AddOption) {
+	c.meter.add(c.name, value)
+}
+
+// recordingHistogram counts the number of recorded values.
+type recordingHistogram struct {
+	noop.Float64Histogram
+	meter *recordingMeter
+	name  string
+}
+
+func (h *recordingHistogram) Record(_ context.Context, _ float64, _ ...metric.RecordOption) {
+	h.meter.add(h.name, 1)
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+// defaultClusterName is the cluster name Hazelcast uses if none is configured.
+const defaultClusterName = "dev"
+
+// Health describes the connectivity of a HazelcastCache, e.g. for readiness probes. Members is zero while disconnected.
+// For caches created using NewHazelcastCacheWithClient, the cluster name has to be passed using WithClusterName.
+// The members of their client are tracked from the next membership change or reconnect on, unless the module
+// is built with the hazelcastinternal tag, which allows reading the members the client already knows.
+type Health struct {
+	Connected       bool
+	ClusterName     string
+	Members         int
+	State           hazelcast.LifecycleState
+	LastStateChange time.Time
+}
+
+// LifecycleEvent is passed to lifecycle listeners whenever the state of the Hazelcast client changes.
+type LifecycleEvent struct {
+	State     hazelcast.LifecycleState
+	Connected bool
+	Time      time.Time
+}
+
+// LifecycleListener is called for every lifecycle event. It is called on the event goroutine of the client
+// and must not block.
+type LifecycleListener func(event LifecycleEvent)
+
+// lifecycleTracker keeps track of the state and members of a client and dispatches its lifecycle events.
+type lifecycleTracker struct {
+	mu        sync.RWMutex
+	state     hazelcast.LifecycleState
+	connected bool
+	changed   time.Time
+	members   map[types.UUID]struct{}
+	listeners map[types.UUID]LifecycleListener
+}
+
+func newLifecycleTracker() *lifecycleTracker {
+	return &lifecycleTracker{
+		state:     hazelcast.LifecycleStateStarting,
+		changed:   time.Now(),
+		members:   make(map[types.UUID]struct{}),
+		listeners: make(map[types.UUID]LifecycleListener),
+	}
+}
+
+// register adds the tracker to a configuration, so it observes all events from the start of the client on.
+func (t *lifecycleTracker) register(config *hazelcast.Config) {
+	config.AddLifecycleListener(t.handle)
+	config.AddMembershipListener(t.handleMembership)
+}
+
+// attach adds the tracker to a running client. Since the client does not publish the members it already knows
+// to new listeners, they are seeded from clientMembers.
+func (t *lifecycleTracker) attach(client *hazelcast.Client) {
+	t.initialize(client)
+
+	// Registration only fails if the client is already shutting down, which is reflected by the initial state.
+	_, _ = client.AddLifecycleListener(t.handle)
+	_, _ = client.AddMembershipListener(t.handleMembership)
+
+	for _, member := range clientMembers(client) {
+		t.handleMembership(cluster.MembershipStateChanged{Member: member, State: cluster.MembershipStateAdded})
+	}
+}
+
+// initialize derives the state from the client, unless an event about its connection has already been handled.
+func (t *lifecycleTracker) initialize(client *hazelcast.Client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state != hazelcast.LifecycleStateStarting && t.state != hazelcast.LifecycleStateStarted {
+		return
+	}
+
+	t.connected = client.Running()
+	if t.connected {
+		t.state = hazelcast.LifecycleStateConnected
+	} else {
+		t.state = hazelcast.LifecycleStateShutDown
+	}
+	t.changed = time.Now()
+}
+
+func (t *lifecycleTracker) handle(event hazelcast.LifecycleStateChanged) {
+	t.mu.Lock()
+	t.state = event.State
+	t.changed = time.Now()
+	switch event.State {
+	case hazelcast.LifecycleStateConnected, hazelcast.LifecycleStateChangedCluster:
+		t.connected = true
+	case hazelcast.LifecycleStateDisconnected, hazelcast.LifecycleStateShuttingDown, hazelcast.LifecycleStateShutDown:
+		// The client forgets the members without publishing events and announces them again once reconnected.
+		t.connected = false
+		clear(t.members)
+	default:
+	}
+
+	lifecycleEvent := LifecycleEvent{State: t.state, Connected: t.connected, Time: t.changed}
+	listeners := make([]LifecycleListener, 0, len(t.listeners))
+	for _, listener := range t.listeners {
+		listeners = append(listeners, listener)
+	}
+	t.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(lifecycleEvent)
+	}
+}
+
+func (t *lifecycleTracker) handleMembership(event cluster.MembershipStateChanged) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if event.State == cluster.MembershipStateAdded {
+		t.members[event.Member.UUID] = struct{}{}
+	} else {
+		delete(t.members, event.Member.UUID)
+	}
+}
+
+func (t *lifecycleTracker) add(listener LifecycleListener) types.UUID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := types.NewUUID()
+	t.listeners[id] = listener
+	return id
+}
+
+func (t *lifecycleTracker) remove(id types.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.listeners, id)
+}
+
+func (t *lifecycleTracker) health() Health {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return Health{Connected: t.connected, Members: len(t.members), State: t.state, LastStateChange: t.changed}
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !hazelcastinternal
+
+package cache
+
+import (
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+)
+
+// clientMembers returns the members a running client knows. The public client API does not expose them.
+func clientMembers(*hazelcast.Client) []cluster.MemberInfo {
+	return nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build hazelcastinternal
+
+package cache
+
+import (
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+)
+
+// clientMembers returns the members a running client knows using the internal API of the client.
+func clientMembers(client *hazelcast.Client) []cluster.MemberInfo {
+	return hazelcast.NewClientInternal(client).OrderedMembers()
+}
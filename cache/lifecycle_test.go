@@ -0,0 +1,57 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycleTracker(t *testing.T) {
+	assertions := assert.New(t)
+
+	tracker := newLifecycleTracker()
+	tracker.register(&hazelcast.Config{})
+
+	var events []LifecycleEvent
+	id := tracker.add(func(event LifecycleEvent) {
+		events = append(events, event)
+	})
+
+	member := cluster.MemberInfo{UUID: types.NewUUID()}
+	tracker.handle(hazelcast.LifecycleStateChanged{State: hazelcast.LifecycleStateConnected})
+	tracker.handleMembership(cluster.MembershipStateChanged{Member: member, State: cluster.MembershipStateAdded})
+	tracker.handleMembership(cluster.MembershipStateChanged{
+		Member: cluster.MemberInfo{UUID: types.NewUUID()},
+		State:  cluster.MembershipStateAdded,
+	})
+
+	health := tracker.health()
+	assertions.True(health.Connected)
+	assertions.Equal(2, health.Members)
+	assertions.Equal(hazelcast.LifecycleStateConnected, health.State)
+
+	tracker.handleMembership(cluster.MembershipStateChanged{Member: member, State: cluster.MembershipStateRemoved})
+	assertions.Equal(1, tracker.health().Members)
+
+	tracker.handle(hazelcast.LifecycleStateChanged{State: hazelcast.LifecycleStateDisconnected})
+
+	health = tracker.health()
+	assertions.False(health.Connected)
+	assertions.Zero(health.Members)
+	assertions.Equal(health.LastStateChange, events[1].Time)
+
+	tracker.remove(id)
+	tracker.handle(hazelcast.LifecycleStateChanged{State: hazelcast.LifecycleStateConnected})
+
+	assertions.Len(events, 2)
+	assertions.True(events[0].Connected)
+	assertions.Equal(hazelcast.LifecycleStateDisconnected, events[1].State)
+	assertions.False(events[1].Connected)
+}
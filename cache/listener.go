@@ -4,11 +4,135 @@
 
 package cache
 
-import "github.com/hazelcast/hazelcast-go-client"
+import (
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
 
+// Listener receives the entry events of a cache map. See EventListener for a listener independent of Hazelcast types.
 type Listener[T any] interface {
 	OnAdd(event *hazelcast.EntryNotified, obj T)
 	OnUpdate(event *hazelcast.EntryNotified, obj T, oldObj T)
 	OnDelete(event *hazelcast.EntryNotified)
 	OnError(event *hazelcast.EntryNotified, err error)
 }
+
+// ExtendedListener is an optional extension of Listener for listeners that need to observe every removal.
+// Listeners implementing it are also notified about evicted and expired entries as well as cleared and evicted maps.
+// Deleted entries are passed to OnDeleteWithValue instead of OnDelete.
+// The last known value is nil if it is not available, e.g. for listeners registered using WithoutValues.
+type ExtendedListener[T any] interface {
+	Listener[T]
+	OnDeleteWithValue(event *hazelcast.EntryNotified, oldObj *T)
+	OnEvict(event *hazelcast.EntryNotified, oldObj *T)
+	OnExpire(event *hazelcast.EntryNotified, oldObj *T)
+	OnClear(event *hazelcast.EntryNotified)
+}
+
+// ListenerHandle identifies a registered listener and is used to remove it again.
+type ListenerHandle struct {
+	MapName string
+	ID      string
+	uuid    types.UUID
+}
+
+func newListenerHandle(mapName string, uuid types.UUID) ListenerHandle {
+	return ListenerHandle{MapName: mapName, ID: uuid.String(), uuid: uuid}
+}
+
+// ListenerOption configures the registration of a listener.
+type ListenerOption func(*listenerOptions)
+
+type listenerOptions struct {
+	includeValue bool
+}
+
+func newListenerOptions(opts []ListenerOption) listenerOptions {
+	o := listenerOptions{includeValue: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithoutValues registers a listener that is only interested in keys.
+// The values are neither transferred nor decoded, so the listener receives zero values instead.
+func WithoutValues() ListenerOption {
+	return func(o *listenerOptions) {
+		o.includeValue = false
+	}
+}
+
+// dispatchEvent decodes the values of an event and passes it to the matching callback of the listener.
+func dispatchEvent[T any](event *hazelcast.EntryNotified, listener Listener[T], codec Codec[T], includeValue bool) {
+	switch event.EventType {
+	case hazelcast.EntryAdded:
+		var obj T
+		if includeValue {
+			var err error
+			if obj, err = decode(codec, event.MapName, event.Key, event.Value); err != nil {
+				listener.OnError(event, err)
+				return
+			}
+		}
+
+		listener.OnAdd(event, obj)
+
+	case hazelcast.EntryUpdated:
+		var obj, oldObj T
+		if includeValue {
+			var err error
+			if obj, err = decode(codec, event.MapName, event.Key, event.Value); err != nil {
+				listener.OnError(event, err)
+				return
+			}
+
+			if oldObj, err = decode(codec, event.MapName, event.Key, event.OldValue); err != nil {
+				listener.OnError(event, err)
+				return
+			}
+		}
+
+		listener.OnUpdate(event, obj, oldObj)
+
+	case hazelcast.EntryRemoved, hazelcast.EntryEvicted, hazelcast.EntryExpired:
+		dispatchRemoval(event, listener, codec, includeValue)
+
+	case hazelcast.EntryAllCleared, hazelcast.EntryAllEvicted:
+		if extendedListener, ok := listener.(ExtendedListener[T]); ok {
+			extendedListener.OnClear(event)
+		}
+
+	default:
+	}
+}
+
+// dispatchRemoval passes a removed entry to the listener. Evictions and expiries are only passed to an ExtendedListener.
+func dispatchRemoval[T any](event *hazelcast.EntryNotified, listener Listener[T], codec Codec[T], includeValue bool) {
+	extendedListener, ok := listener.(ExtendedListener[T])
+	if !ok {
+		if event.EventType == hazelcast.EntryRemoved {
+			listener.OnDelete(event)
+		}
+		return
+	}
+
+	var oldObj *T
+	if includeValue && event.OldValue != nil {
+		decoded, err := decode(codec, event.MapName, event.Key, event.OldValue)
+		if err != nil {
+			listener.OnError(event, err)
+			return
+		}
+		oldObj = &decoded
+	}
+
+	switch event.EventType {
+	case hazelcast.EntryEvicted:
+		extendedListener.OnEvict(event, oldObj)
+	case hazelcast.EntryExpired:
+		extendedListener.OnExpire(event, oldObj)
+	default:
+		extendedListener.OnDeleteWithValue(event, oldObj)
+	}
+}
@@ -5,34 +5,180 @@
 package cache
 
 import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
 	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/assert"
 )
 
 type MockListener[T TestDummy] struct {
-	onAddCalled    bool
-	onUpdateCalled bool
-	onDeleteCalled bool
-	onErrorCalled  bool
-	err            error
+	onAddCalled    atomic.Bool
+	onUpdateCalled atomic.Bool
+	onDeleteCalled atomic.Bool
+	onErrorCalled  atomic.Bool
+
+	mu     sync.Mutex
+	err    error
+	keys   []string
+	values []TestDummy
 }
 
 // event, obj
-func (m *MockListener[T]) OnAdd(_ *hazelcast.EntryNotified, _ TestDummy) {
-	m.onAddCalled = true
+func (m *MockListener[T]) OnAdd(event *hazelcast.EntryNotified, obj TestDummy) {
+	m.onAddCalled.Store(true)
+	m.record(event, obj)
 }
 
 // event, obj, oldObj
-func (m *MockListener[T]) OnUpdate(_ *hazelcast.EntryNotified, _ TestDummy, _ TestDummy) {
-	m.onUpdateCalled = true
+func (m *MockListener[T]) OnUpdate(event *hazelcast.EntryNotified, obj TestDummy, _ TestDummy) {
+	m.onUpdateCalled.Store(true)
+	m.record(event, obj)
 }
 
 // event
-func (m *MockListener[T]) OnDelete(_ *hazelcast.EntryNotified) {
-	m.onDeleteCalled = true
+func (m *MockListener[T]) OnDelete(event *hazelcast.EntryNotified) {
+	m.onDeleteCalled.Store(true)
+	m.record(event, TestDummy{})
 }
 
 // event
 func (m *MockListener[T]) OnError(_ *hazelcast.EntryNotified, err error) {
-	m.onErrorCalled = true
+	m.onErrorCalled.Store(true)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.err = err
 }
+
+// lastError returns the error passed to the most recent OnError call.
+func (m *MockListener[T]) lastError() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.err
+}
+
+func (m *MockListener[T]) record(event *hazelcast.EntryNotified, obj TestDummy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, _ := event.Key.(string)
+	m.keys = append(m.keys, key)
+	m.values = append(m.values, obj)
+}
+
+// receivedKeys returns the keys of all events received so far.
+func (m *MockListener[T]) receivedKeys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.keys...)
+}
+
+// receivedValues returns the values of all events received so far.
+func (m *MockListener[T]) receivedValues() []TestDummy {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]TestDummy(nil), m.values...)
+}
+
+type ExtendedMockListener struct {
+	MockListener[TestDummy]
+
+	deleted []*TestDummy
+	evicted []*TestDummy
+	expired []*TestDummy
+	cleared []int
+}
+
+func (m *ExtendedMockListener) OnDeleteWithValue(_ *hazelcast.EntryNotified, oldObj *TestDummy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleted = append(m.deleted, oldObj)
+}
+
+func (m *ExtendedMockListener) OnEvict(_ *hazelcast.EntryNotified, oldObj *TestDummy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evicted = append(m.evicted, oldObj)
+}
+
+func (m *ExtendedMockListener) OnExpire(_ *hazelcast.EntryNotified, oldObj *TestDummy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expired = append(m.expired, oldObj)
+}
+
+func (m *ExtendedMockListener) OnClear(event *hazelcast.EntryNotified) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cleared = append(m.cleared, event.NumberOfAffectedEntries)
+}
+
+// removals returns the number of deleted, evicted and expired entries as well as map clears received so far.
+func (m *ExtendedMockListener) removals() (int, int, int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.deleted), len(m.evicted), len(m.expired), len(m.cleared)
+}
+
+type MockEventListener struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (m *MockEventListener) OnAdd(event Event, _ TestDummy) {
+	m.record(event)
+}
+
+func (m *MockEventListener) OnUpdate(event Event, _ TestDummy, _ TestDummy) {
+	m.record(event)
+}
+
+func (m *MockEventListener) OnDelete(event Event, _ *TestDummy) {
+	m.record(event)
+}
+
+func (m *MockEventListener) OnClear(event Event) {
+	m.record(event)
+}
+
+func (m *MockEventListener) OnError(event Event, _ error) {
+	m.record(event)
+}
+
+func (m *MockEventListener) record(event Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+}
+
+// receivedKinds returns the kinds of all events received so far.
+func (m *MockEventListener) receivedKinds() []EventKind {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	kinds := make([]EventKind, 0, len(m.events))
+	for _, event := range m.events {
+		kinds = append(kinds, event.Kind)
+	}
+	return kinds
+}
+
+func TestDispatchEvent_DecodeError(t *testing.T) {
+	assertions := assert.New(t)
+	listener := &MockListener[TestDummy]{}
+
+	event := &hazelcast.EntryNotified{EventType: hazelcast.EntryAdded, MapName: "testMap", Key: "corrupt", Value: []byte("not json")}
+	dispatchEvent[TestDummy](event, listener, JSONCodec[TestDummy]{}, true)
+
+	var decodeErr *DecodeError
+	assertions.True(listener.onErrorCalled.Load())
+	assertions.False(listener.onAddCalled.Load())
+	assertions.ErrorAs(listener.lastError(), &decodeErr)
+	assertions.Equal("testMap", decodeErr.MapName)
+	assertions.Equal("corrupt", decodeErr.Key)
+	assertions.ErrorIs(listener.lastError(), ErrNotJSON)
+}
@@ -0,0 +1,1109 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+// MemoryCache is an in-process implementation of QueryableCache.
+// Values are encoded with the configured codec just like in HazelcastCache, so serialization issues surface in the same way.
+// Queries evaluate attributes of JSON encoded values only; values stored with other codecs can only be matched by key.
+// Listeners are notified asynchronously but in order, similar to Hazelcast entry listeners.
+// Since entries expire lazily, expiry events are only fired once an expired entry is accessed.
+// Locks are advisory: unlike Hazelcast, writes to a key locked by another owner are not blocked.
+// Only entry processors implementing LocalEntryProcessor can be executed.
+type MemoryCache[T any] struct {
+	mu         sync.RWMutex
+	maps       map[string]map[string]*memoryEntry
+	listeners  map[string][]*memoryListener[T]
+	dispatcher *eventDispatcher
+	codec      Codec[T]
+	opts       options[T]
+	now        func() time.Time
+
+	locks        map[memoryLockKey]*memoryLock
+	lockReleased chan struct{}
+}
+
+// memoryEntry is a single value of a MemoryCache together with its expiry settings.
+type memoryEntry struct {
+	value      any
+	expiresAt  time.Time
+	maxIdle    time.Duration
+	lastAccess time.Time
+}
+
+type memoryLockKey struct {
+	mapName string
+	key     string
+}
+
+// memoryLock is a re-entrant lock on a single key of a MemoryCache.
+type memoryLock struct {
+	owner     int64
+	count     int
+	expiresAt time.Time
+}
+
+func (l *memoryLock) expired(now time.Time) bool {
+	return !l.expiresAt.IsZero() && !now.Before(l.expiresAt)
+}
+
+// memoryListener is a listener registered on a MemoryCache together with its filters.
+type memoryListener[T any] struct {
+	uuid     types.UUID
+	listener Listener[T]
+	key      *string
+	query    predicate.Predicate
+	options  listenerOptions
+	removed  atomic.Bool
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	if !e.expiresAt.IsZero() && !now.Before(e.expiresAt) {
+		return true
+	}
+	return e.maxIdle > 0 && now.Sub(e.lastAccess) >= e.maxIdle
+}
+
+var (
+	_ QueryableCache[any]  = (*MemoryCache[any])(nil)
+	_ ConcurrentCache[any] = (*MemoryCache[any])(nil)
+	_ PatchingCache[any]   = (*MemoryCache[any])(nil)
+)
+
+// NewMemoryCache creates a new empty MemoryCache.
+func NewMemoryCache[T any](opts ...Option[T]) *MemoryCache[T] {
+	o := newOptions(opts)
+	return &MemoryCache[T]{
+		maps:       make(map[string]map[string]*memoryEntry),
+		listeners:  make(map[string][]*memoryListener[T]),
+		dispatcher: newEventDispatcher(),
+		codec:      o.codec,
+		opts:       o,
+		now:        time.Now,
+
+		locks:        make(map[memoryLockKey]*memoryLock),
+		lockReleased: make(chan struct{}),
+	}
+}
+
+func (c *MemoryCache[T]) Put(mapName string, key string, value T) error {
+	return c.PutCtx(context.Background(), mapName, key, value)
+}
+
+func (c *MemoryCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	return c.PutWithTTLAndMaxIdleCtx(ctx, mapName, key, value, 0, 0)
+}
+
+func (c *MemoryCache[T]) PutWithTTL(mapName string, key string, value T, ttl time.Duration) error {
+	return c.PutWithTTLCtx(context.Background(), mapName, key, value, ttl)
+}
+
+func (c *MemoryCache[T]) PutWithTTLCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration) error {
+	return c.PutWithTTLAndMaxIdleCtx(ctx, mapName, key, value, ttl, 0)
+}
+
+func (c *MemoryCache[T]) PutWithTTLAndMaxIdle(mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error {
+	return c.PutWithTTLAndMaxIdleCtx(context.Background(), mapName, key, value, ttl, maxIdle)
+}
+
+func (c *MemoryCache[T]) PutWithTTLAndMaxIdleCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	value T,
+	ttl time.Duration,
+	maxIdle time.Duration,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	encoded, err := c.codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entry := &memoryEntry{value: encoded, maxIdle: maxIdle, lastAccess: now}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	c.store(mapName, key, entry, now)
+
+	return nil
+}
+
+func (c *MemoryCache[T]) Get(mapName string, key string) (*T, error) {
+	return c.GetCtx(context.Background(), mapName, key)
+}
+
+func (c *MemoryCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	entry := c.lookup(mapName, key, now)
+	if entry != nil {
+		entry.lastAccess = now
+	}
+	c.mu.Unlock()
+
+	if entry == nil {
+		return c.opts.miss()
+	}
+
+	decodedValue, err := decode(c.codec, mapName, key, entry.value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decodedValue, nil
+}
+
+func (c *MemoryCache[T]) Delete(mapName string, key string) error {
+	return c.DeleteCtx(context.Background(), mapName, key)
+}
+
+func (c *MemoryCache[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(mapName, key, c.now(), hazelcast.EntryRemoved)
+
+	return nil
+}
+
+func (c *MemoryCache[T]) PutAll(mapName string, values map[string]T) error {
+	return c.PutAllCtx(context.Background(), mapName, values)
+}
+
+func (c *MemoryCache[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	encoded := make(map[string]any, len(values))
+	for key, value := range values {
+		encodedValue, err := c.codec.Encode(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value with key '%s': %w", key, err)
+		}
+		encoded[key] = encodedValue
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for key, value := range encoded {
+		c.store(mapName, key, &memoryEntry{value: value, lastAccess: now}, now)
+	}
+
+	return nil
+}
+
+func (c *MemoryCache[T]) GetAll(mapName string, keys []string) (map[string]T, error) {
+	return c.GetAllCtx(context.Background(), mapName, keys)
+}
+
+func (c *MemoryCache[T]) GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	entries := make(map[string]any, len(keys))
+	for _, key := range keys {
+		if entry := c.lookup(mapName, key, now); entry != nil {
+			entry.lastAccess = now
+			entries[key] = entry.value
+		}
+	}
+	c.mu.Unlock()
+
+	values := make(map[string]T, len(entries))
+	errs := make(map[string]error)
+	for key, data := range entries {
+		value, err := decode(c.codec, mapName, key, data)
+		if c.opts.skipDecodeError(err) {
+			continue
+		} else if err != nil {
+			errs[key] = err
+			continue
+		}
+
+		values[key] = value
+	}
+
+	if len(errs) > 0 {
+		return values, &BatchError{Errors: errs}
+	}
+
+	return values, nil
+}
+
+func (c *MemoryCache[T]) DeleteAll(mapName string, keys []string) error {
+	return c.DeleteAllCtx(context.Background(), mapName, keys)
+}
+
+func (c *MemoryCache[T]) DeleteAllCtx(ctx context.Context, mapName string, keys []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, key := range keys {
+		c.remove(mapName, key, now, hazelcast.EntryRemoved)
+	}
+
+	return nil
+}
+
+func (c *MemoryCache[T]) GetQuery(mapName string, query predicate.Predicate) ([]T, error) {
+	return c.GetQueryCtx(context.Background(), mapName, query)
+}
+
+func (c *MemoryCache[T]) GetQueryCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]T, error) {
+	entries, err := c.GetQueryEntriesCtx(ctx, mapName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return entryValues(entries), nil
+}
+
+func (c *MemoryCache[T]) GetQueryEntries(mapName string, query predicate.Predicate) ([]Entry[T], error) {
+	return c.GetQueryEntriesCtx(context.Background(), mapName, query)
+}
+
+func (c *MemoryCache[T]) GetQueryEntriesCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]Entry[T], error) {
+	entries, err := c.query(ctx, mapName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	unmarshalledEntries := make([]Entry[T], 0, len(entries))
+	for _, entry := range entries {
+		value, err := decode(c.codec, mapName, entry.Key, entry.Value)
+		if c.opts.skipDecodeError(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		unmarshalledEntries = append(unmarshalledEntries, Entry[T]{Key: entry.Key, Value: value})
+	}
+
+	return unmarshalledEntries, nil
+}
+
+func (c *MemoryCache[T]) GetQueryKeys(mapName string, query predicate.Predicate) ([]string, error) {
+	return c.GetQueryKeysCtx(context.Background(), mapName, query)
+}
+
+func (c *MemoryCache[T]) GetQueryKeysCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]string, error) {
+	entries, err := c.query(ctx, mapName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+
+	return keys, nil
+}
+
+func (c *MemoryCache[T]) GetQueryPage(mapName string, query predicate.Predicate, page int, pageSize int) (*Page[T], error) {
+	return c.GetQueryPageCtx(context.Background(), mapName, query, page, pageSize)
+}
+
+func (c *MemoryCache[T]) GetQueryPageCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	page int,
+	pageSize int,
+) (*Page[T], error) {
+	return queryPage[T](ctx, c, mapName, query, page, pageSize)
+}
+
+func (c *MemoryCache[T]) QueryIter(mapName string, query predicate.Predicate) iter.Seq2[string, T] {
+	seq, _ := c.QueryIterCtx(context.Background(), mapName, query, DefaultQueryPageSize)
+	return seq
+}
+
+func (c *MemoryCache[T]) QueryIterCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	pageSize int,
+) (iter.Seq2[string, T], func() error) {
+	return queryIter[T](ctx, c, mapName, query, pageSize)
+}
+
+// query returns the raw entries of the given map that match the predicate, ordered by their key.
+func (c *MemoryCache[T]) query(ctx context.Context, mapName string, query predicate.Predicate) ([]Entry[any], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := c.snapshot(mapName)
+
+	matching := make([]Entry[any], 0)
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		matches, err := matchesEncoded(query, key, entries[key])
+		if err != nil {
+			return nil, err
+		}
+
+		if matches {
+			matching = append(matching, Entry[any]{Key: key, Value: entries[key]})
+		}
+	}
+
+	return matching, nil
+}
+
+func (c *MemoryCache[T]) AddListener(mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error) {
+	return c.AddListenerCtx(context.Background(), mapName, listener, opts...)
+}
+
+func (c *MemoryCache[T]) AddListenerCtx(
+	ctx context.Context,
+	mapName string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.addListener(ctx, mapName, &memoryListener[T]{listener: listener, options: newListenerOptions(opts)})
+}
+
+func (c *MemoryCache[T]) AddListenerForKey(
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.AddListenerForKeyCtx(context.Background(), mapName, key, listener, opts...)
+}
+
+func (c *MemoryCache[T]) AddListenerForKeyCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.addListener(ctx, mapName, &memoryListener[T]{listener: listener, key: &key, options: newListenerOptions(opts)})
+}
+
+func (c *MemoryCache[T]) AddListenerWithPredicate(
+	mapName string,
+	query predicate.Predicate,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.AddListenerWithPredicateCtx(context.Background(), mapName, query, listener, opts...)
+}
+
+func (c *MemoryCache[T]) AddListenerWithPredicateCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return c.addListener(ctx, mapName, &memoryListener[T]{listener: listener, query: query, options: newListenerOptions(opts)})
+}
+
+func (c *MemoryCache[T]) RemoveListener(handle ListenerHandle) error {
+	return c.RemoveListenerCtx(context.Background(), handle)
+}
+
+// RemoveListenerCtx removes a listener. Events that have not been delivered to it yet are dropped.
+// Removing a listener that is not registered is a no-op.
+func (c *MemoryCache[T]) RemoveListenerCtx(ctx context.Context, handle ListenerHandle) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.listeners[handle.MapName] = slices.DeleteFunc(c.listeners[handle.MapName], func(registration *memoryListener[T]) bool {
+		if registration.uuid != handle.uuid {
+			return false
+		}
+
+		registration.removed.Store(true)
+		return true
+	})
+
+	return nil
+}
+
+func (c *MemoryCache[T]) addListener(ctx context.Context, mapName string, registration *memoryListener[T]) (ListenerHandle, error) {
+	if err := ctx.Err(); err != nil {
+		return ListenerHandle{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	registration.uuid = types.NewUUID()
+	c.listeners[mapName] = append(c.listeners[mapName], registration)
+	c.dispatcher.start()
+
+	return newListenerHandle(mapName, registration.uuid), nil
+}
+
+// Evict removes an entry like an eviction of Hazelcast would do, firing an eviction event instead of a removal event.
+func (c *MemoryCache[T]) Evict(mapName string, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.remove(mapName, key, c.now(), hazelcast.EntryEvicted)
+}
+
+// EvictAll removes all entries of the given map and notifies the listeners with a single map evicted event.
+func (c *MemoryCache[T]) EvictAll(mapName string) {
+	c.removeAll(mapName, hazelcast.EntryAllEvicted)
+}
+
+// Clear removes all entries of the given map and notifies the listeners with a single map cleared event.
+func (c *MemoryCache[T]) Clear(mapName string) {
+	c.removeAll(mapName, hazelcast.EntryAllCleared)
+}
+
+func (c *MemoryCache[T]) PutIfAbsent(mapName string, key string, value T) (*T, error) {
+	return c.PutIfAbsentCtx(context.Background(), mapName, key, value)
+}
+
+func (c *MemoryCache[T]) PutIfAbsentCtx(ctx context.Context, mapName string, key string, value T) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	encoded, err := c.codec.Encode(value)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	existing := c.lookup(mapName, key, now)
+	if existing == nil {
+		c.store(mapName, key, &memoryEntry{value: encoded, lastAccess: now}, now)
+	}
+	c.mu.Unlock()
+
+	if existing == nil {
+		//nolint:nilnil // No existing value means the value has been stored
+		return nil, nil
+	}
+
+	decodedValue, err := decode(c.codec, mapName, key, existing.value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decodedValue, nil
+}
+
+func (c *MemoryCache[T]) Replace(mapName string, key string, oldValue T, newValue T) (bool, error) {
+	return c.ReplaceCtx(context.Background(), mapName, key, oldValue, newValue)
+}
+
+func (c *MemoryCache[T]) ReplaceCtx(ctx context.Context, mapName string, key string, oldValue T, newValue T) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	encodedOld, err := c.codec.Encode(oldValue)
+	if err != nil {
+		return false, err
+	}
+
+	encodedNew, err := c.codec.Encode(newValue)
+	if err != nil {
+		return false, err
+	}
+
+	return c.replaceRaw(ctx, mapName, key, encodedOld, encodedNew)
+}
+
+func (c *MemoryCache[T]) Update(mapName string, key string, fn func(old *T) (T, error)) (T, error) {
+	return c.UpdateCtx(context.Background(), mapName, key, fn)
+}
+
+func (c *MemoryCache[T]) UpdateCtx(ctx context.Context, mapName string, key string, fn func(old *T) (T, error)) (T, error) {
+	return update(ctx, c, c.codec, mapName, key, fn)
+}
+
+func (c *MemoryCache[T]) Lock(mapName string, key string, lease time.Duration) error {
+	return c.LockCtx(context.Background(), mapName, key, lease)
+}
+
+func (c *MemoryCache[T]) LockCtx(ctx context.Context, mapName string, key string, lease time.Duration) error {
+	_, err := c.acquireLock(ctx, mapName, key, lease, nil)
+	return err
+}
+
+func (c *MemoryCache[T]) TryLock(mapName string, key string, timeout time.Duration, lease time.Duration) (bool, error) {
+	return c.TryLockCtx(context.Background(), mapName, key, timeout, lease)
+}
+
+func (c *MemoryCache[T]) TryLockCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	timeout time.Duration,
+	lease time.Duration,
+) (bool, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	return c.acquireLock(ctx, mapName, key, lease, timer.C)
+}
+
+func (c *MemoryCache[T]) Unlock(mapName string, key string) error {
+	return c.UnlockCtx(context.Background(), mapName, key)
+}
+
+func (c *MemoryCache[T]) UnlockCtx(ctx context.Context, mapName string, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lockKey := memoryLockKey{mapName, key}
+	lock, ok := c.locks[lockKey]
+	if !ok || lock.expired(c.now()) || lock.owner != lockOwner(ctx) {
+		return fmt.Errorf("failed to unlock key '%s': %w", key, ErrLockNotHeld)
+	}
+
+	lock.count--
+	if lock.count == 0 {
+		delete(c.locks, lockKey)
+		close(c.lockReleased)
+		c.lockReleased = make(chan struct{})
+	}
+
+	return nil
+}
+
+// acquireLock waits until the lock is available or the timeout channel fires. A nil timeout waits forever.
+func (c *MemoryCache[T]) acquireLock(
+	ctx context.Context,
+	mapName string,
+	key string,
+	lease time.Duration,
+	timeout <-chan time.Time,
+) (bool, error) {
+	lockKey := memoryLockKey{mapName, key}
+	owner := lockOwner(ctx)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		c.mu.Lock()
+		now := c.now()
+		lock, ok := c.locks[lockKey]
+		if ok && lock.expired(now) {
+			delete(c.locks, lockKey)
+			ok = false
+		}
+
+		if !ok || lock.owner == owner {
+			if !ok {
+				lock = &memoryLock{owner: owner}
+				c.locks[lockKey] = lock
+			}
+
+			lock.count++
+			lock.expiresAt = time.Time{}
+			if lease > 0 {
+				lock.expiresAt = now.Add(lease)
+			}
+
+			c.mu.Unlock()
+			return true, nil
+		}
+
+		released := c.lockReleased
+		var leaseRemaining time.Duration
+		if !lock.expiresAt.IsZero() {
+			leaseRemaining = lock.expiresAt.Sub(now)
+		}
+		c.mu.Unlock()
+
+		if retry, err := waitForLock(ctx, released, leaseRemaining, timeout); !retry {
+			return false, err
+		}
+	}
+}
+
+// waitForLock blocks until a lock has been released, its lease expired, the timeout fired or the context is done.
+// It reports whether acquiring the lock should be retried.
+func waitForLock(ctx context.Context, released <-chan struct{}, leaseRemaining time.Duration, timeout <-chan time.Time) (bool, error) {
+	var leaseExpired <-chan time.Time
+	if leaseRemaining > 0 {
+		timer := time.NewTimer(leaseRemaining)
+		defer timer.Stop()
+		leaseExpired = timer.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	case <-timeout:
+		return false, nil
+	case <-released:
+		return true, nil
+	case <-leaseExpired:
+		return true, nil
+	}
+}
+
+func (c *MemoryCache[T]) getRaw(ctx context.Context, mapName string, key string) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entry := c.lookup(mapName, key, now)
+	if entry == nil {
+		//nolint:nilnil // Cache miss is a valid state, not an error
+		return nil, nil
+	}
+
+	entry.lastAccess = now
+	return entry.value, nil
+}
+
+func (c *MemoryCache[T]) putIfAbsentRaw(ctx context.Context, mapName string, key string, value any) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.lookup(mapName, key, now) != nil {
+		return false, nil
+	}
+
+	c.store(mapName, key, &memoryEntry{value: value, lastAccess: now}, now)
+	return true, nil
+}
+
+func (c *MemoryCache[T]) replaceRaw(ctx context.Context, mapName string, key string, oldValue any, newValue any) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entry := c.lookup(mapName, key, now)
+	if entry == nil || !reflect.DeepEqual(entry.value, oldValue) {
+		return false, nil
+	}
+
+	c.store(mapName, key, &memoryEntry{value: newValue, lastAccess: now}, now)
+	return true, nil
+}
+
+func (c *MemoryCache[T]) ExecuteOnKey(mapName string, key string, processor EntryProcessor) (any, error) {
+	return c.ExecuteOnKeyCtx(context.Background(), mapName, key, processor)
+}
+
+func (c *MemoryCache[T]) ExecuteOnKeyCtx(ctx context.Context, mapName string, key string, processor EntryProcessor) (any, error) {
+	results, err := c.execute(ctx, mapName, []string{key}, processor)
+	if err != nil {
+		return nil, err
+	}
+
+	return results[key], nil
+}
+
+func (c *MemoryCache[T]) ExecuteOnKeys(mapName string, keys []string, processor EntryProcessor) (map[string]any, error) {
+	return c.ExecuteOnKeysCtx(context.Background(), mapName, keys, processor)
+}
+
+func (c *MemoryCache[T]) ExecuteOnKeysCtx(
+	ctx context.Context,
+	mapName string,
+	keys []string,
+	processor EntryProcessor,
+) (map[string]any, error) {
+	return c.execute(ctx, mapName, keys, processor)
+}
+
+func (c *MemoryCache[T]) ExecuteOnEntries(mapName string, query predicate.Predicate, processor EntryProcessor) (map[string]any, error) {
+	return c.ExecuteOnEntriesCtx(context.Background(), mapName, query, processor)
+}
+
+func (c *MemoryCache[T]) ExecuteOnEntriesCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	processor EntryProcessor,
+) (map[string]any, error) {
+	keys, err := c.GetQueryKeysCtx(ctx, mapName, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.execute(ctx, mapName, keys, processor)
+}
+
+func (c *MemoryCache[T]) Patch(mapName string, key string, operations ...PatchOperation) (*T, error) {
+	return c.PatchCtx(context.Background(), mapName, key, operations...)
+}
+
+func (c *MemoryCache[T]) PatchCtx(ctx context.Context, mapName string, key string, operations ...PatchOperation) (*T, error) {
+	result, err := c.ExecuteOnKeyCtx(ctx, mapName, key, NewJSONPatchProcessor(operations...))
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	value, err := decode(c.codec, mapName, key, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+func (c *MemoryCache[T]) PatchEntries(mapName string, query predicate.Predicate, operations ...PatchOperation) (map[string]T, error) {
+	return c.PatchEntriesCtx(context.Background(), mapName, query, operations...)
+}
+
+func (c *MemoryCache[T]) PatchEntriesCtx(
+	ctx context.Context,
+	mapName string,
+	query predicate.Predicate,
+	operations ...PatchOperation,
+) (map[string]T, error) {
+	results, err := c.ExecuteOnEntriesCtx(ctx, mapName, query, NewJSONPatchProcessor(operations...))
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeResults(c.codec, mapName, results)
+}
+
+// execute applies a LocalEntryProcessor to the given keys. Like on a Hazelcast member, each entry is processed
+// atomically, but entries that have been processed before an error occurred keep their new values.
+func (c *MemoryCache[T]) execute(ctx context.Context, mapName string, keys []string, processor EntryProcessor) (map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	localProcessor, ok := processor.(LocalEntryProcessor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T cannot be applied in-process", ErrProcessorNotSupported, processor)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	results := make(map[string]any, len(keys))
+	for _, key := range keys {
+		var value any
+		entry := c.lookup(mapName, key, now)
+		if entry != nil {
+			value = entry.value
+		}
+
+		newValue, result, err := localProcessor.Apply(key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case newValue == nil:
+			c.remove(mapName, key, now, hazelcast.EntryRemoved)
+		case entry == nil:
+			c.store(mapName, key, &memoryEntry{value: newValue, lastAccess: now}, now)
+		case !reflect.DeepEqual(entry.value, newValue):
+			// The expiry settings of processed entries are kept.
+			processed := *entry
+			processed.value = newValue
+			processed.lastAccess = now
+			c.store(mapName, key, &processed, now)
+		}
+
+		if result != nil {
+			results[key] = result
+		}
+	}
+
+	return results, nil
+}
+
+// Close stops the delivery of events to listeners. Events that have not been delivered yet are dropped.
+func (c *MemoryCache[T]) Close() {
+	c.dispatcher.stop()
+}
+
+// store writes an entry and notifies the listeners. It must be called while holding the write lock.
+func (c *MemoryCache[T]) store(mapName string, key string, entry *memoryEntry, now time.Time) {
+	mp, ok := c.maps[mapName]
+	if !ok {
+		mp = make(map[string]*memoryEntry)
+		c.maps[mapName] = mp
+	}
+
+	oldEntry := c.lookup(mapName, key, now)
+	mp[key] = entry
+
+	if oldEntry != nil {
+		c.notify(mapName, hazelcast.EntryUpdated, key, entry.value, oldEntry.value)
+	} else {
+		c.notify(mapName, hazelcast.EntryAdded, key, entry.value, nil)
+	}
+}
+
+// remove deletes an entry and notifies the listeners. It must be called while holding the write lock.
+func (c *MemoryCache[T]) remove(mapName string, key string, now time.Time, eventType hazelcast.EntryEventType) {
+	entry := c.lookup(mapName, key, now)
+	if entry == nil {
+		return
+	}
+
+	delete(c.maps[mapName], key)
+	c.notify(mapName, eventType, key, nil, entry.value)
+}
+
+// removeAll deletes all entries of a map and notifies the listeners with a single map event.
+func (c *MemoryCache[T]) removeAll(mapName string, eventType hazelcast.EntryEventType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	affected := len(c.maps[mapName])
+	delete(c.maps, mapName)
+
+	for _, registration := range c.listeners[mapName] {
+		event := &hazelcast.EntryNotified{
+			MapName:                 mapName,
+			EventType:               eventType,
+			NumberOfAffectedEntries: affected,
+		}
+
+		c.dispatcher.enqueue(func() {
+			if !registration.removed.Load() {
+				dispatchEvent(event, registration.listener, c.codec, registration.options.includeValue)
+			}
+		})
+	}
+}
+
+// lookup returns the entry stored under the given key or nil if there is none.
+// Expired entries are removed on access. It must be called while holding the write lock.
+func (c *MemoryCache[T]) lookup(mapName string, key string, now time.Time) *memoryEntry {
+	entry, ok := c.maps[mapName][key]
+	if !ok {
+		return nil
+	}
+
+	if entry.expired(now) {
+		delete(c.maps[mapName], key)
+		c.notify(mapName, hazelcast.EntryExpired, key, nil, entry.value)
+		return nil
+	}
+
+	return entry
+}
+
+// snapshot returns a copy of all values of the given map that have not expired yet.
+func (c *MemoryCache[T]) snapshot(mapName string) map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entries := make(map[string]any, len(c.maps[mapName]))
+	for key := range c.maps[mapName] {
+		if entry := c.lookup(mapName, key, now); entry != nil {
+			entries[key] = entry.value
+		}
+	}
+
+	return entries
+}
+
+// notify schedules the delivery of an event to all listeners of the given map whose filters match.
+// It must be called while holding the write lock to preserve the order of events.
+func (c *MemoryCache[T]) notify(mapName string, eventType hazelcast.EntryEventType, key string, value any, oldValue any) {
+	for _, registration := range c.listeners[mapName] {
+		if registration.key != nil && *registration.key != key {
+			continue
+		}
+
+		event := &hazelcast.EntryNotified{
+			MapName:   mapName,
+			Key:       key,
+			EventType: eventType,
+		}
+
+		if registration.options.includeValue {
+			event.Value = value
+			event.OldValue = oldValue
+		}
+
+		deliver := func() {
+			dispatchEvent(event, registration.listener, c.codec, registration.options.includeValue)
+		}
+
+		if registration.query != nil {
+			filterValue := value
+			if value == nil {
+				filterValue = oldValue
+			}
+
+			matches, err := matchesEncoded(registration.query, key, filterValue)
+			switch {
+			case err != nil:
+				deliver = func() {
+					registration.listener.OnError(event, err)
+				}
+			case !matches:
+				continue
+			}
+		}
+
+		c.dispatcher.enqueue(func() {
+			if !registration.removed.Load() {
+				deliver()
+			}
+		})
+	}
+}
+
+// matchesEncoded evaluates a predicate against an encoded value.
+// Only the attributes of JSON encoded values can be resolved, other values can only be matched by key.
+func matchesEncoded(query predicate.Predicate, key string, value any) (bool, error) {
+	var document any
+	if bytes, ok := value.(serialization.JSON); ok {
+		if err := json.Unmarshal(bytes, &document); err != nil {
+			return false, err
+		}
+	}
+
+	return evaluatePredicate(query, key, document)
+}
+
+// eventDispatcher delivers events sequentially on a separate goroutine.
+// Its queue is unbounded, so listeners may safely write to the cache they are registered on.
+type eventDispatcher struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []func()
+	running bool
+	stopped bool
+}
+
+func newEventDispatcher() *eventDispatcher {
+	d := new(eventDispatcher)
+	d.cond = sync.NewCond(&d.mu)
+	return d
+}
+
+func (d *eventDispatcher) start() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.running || d.stopped {
+		return
+	}
+
+	d.running = true
+	go d.run()
+}
+
+func (d *eventDispatcher) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopped = true
+	d.queue = nil
+	d.cond.Broadcast()
+}
+
+func (d *eventDispatcher) enqueue(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+
+	d.queue = append(d.queue, fn)
+	d.cond.Signal()
+}
+
+func (d *eventDispatcher) run() {
+	for {
+		d.mu.Lock()
+		for len(d.queue) == 0 && !d.stopped {
+			d.cond.Wait()
+		}
+
+		if d.stopped {
+			d.mu.Unlock()
+			return
+		}
+
+		fn := d.queue[0]
+		d.queue[0] = nil
+		d.queue = d.queue[1:]
+		d.mu.Unlock()
+
+		fn()
+	}
+}
@@ -0,0 +1,683 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache_PutGetDelete(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+
+	assertions.NoError(memoryCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+
+	dummy, err := memoryCache.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Equal("bar", dummy.Foo)
+
+	assertions.NoError(memoryCache.Delete("testMap", "dummy"))
+
+	deletedDummy, err := memoryCache.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Nil(deletedDummy)
+}
+
+func TestMemoryCache_PutWithTTL(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+
+	now := time.Now()
+	memoryCache.now = func() time.Time { return now }
+
+	assertions.NoError(memoryCache.PutWithTTL("testMap", "ttl", TestDummy{Foo: "bar"}, time.Minute))
+	assertions.NoError(memoryCache.PutWithTTLAndMaxIdle("testMap", "idle", TestDummy{Foo: "baz"}, 0, 10*time.Second))
+	assertions.NoError(memoryCache.Put("testMap", "forever", TestDummy{Foo: "fizz"}))
+
+	now = now.Add(5 * time.Second)
+	idle, err := memoryCache.Get("testMap", "idle")
+	assertions.NoError(err)
+	assertions.NotNil(idle)
+
+	now = now.Add(9 * time.Second)
+	idle, err = memoryCache.Get("testMap", "idle")
+	assertions.NoError(err)
+	assertions.NotNil(idle, "access should have reset the idle timer")
+
+	now = now.Add(10 * time.Second)
+	idle, err = memoryCache.Get("testMap", "idle")
+	assertions.NoError(err)
+	assertions.Nil(idle)
+
+	now = now.Add(time.Minute)
+	ttl, err := memoryCache.Get("testMap", "ttl")
+	assertions.NoError(err)
+	assertions.Nil(ttl)
+
+	results, err := memoryCache.GetQuery("testMap", predicate.True())
+	assertions.NoError(err)
+	assertions.Equal([]TestDummy{{Foo: "fizz"}}, results)
+}
+
+func TestMemoryCache_BatchOperations(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+
+	assertions.NoError(memoryCache.PutAll("testMap", map[string]TestDummy{
+		"a": {Foo: "bar"},
+		"b": {Foo: "baz"},
+		"c": {Foo: "fizz"},
+	}))
+
+	values, err := memoryCache.GetAll("testMap", []string{"a", "b", "missing"})
+	assertions.NoError(err)
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}, "b": {Foo: "baz"}}, values)
+
+	memoryCache.maps["testMap"]["corrupt"] = &memoryEntry{value: serialization.JSON(`{"foo": 1}`)}
+
+	values, err = memoryCache.GetAll("testMap", []string{"a", "corrupt"})
+	var batchErr *BatchError
+	assertions.ErrorAs(err, &batchErr)
+	assertions.Contains(batchErr.Errors, "corrupt")
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}}, values)
+
+	assertions.NoError(memoryCache.DeleteAll("testMap", []string{"a", "b", "corrupt"}))
+
+	values, err = memoryCache.GetAll("testMap", []string{"a", "b", "c"})
+	assertions.NoError(err)
+	assertions.Equal(map[string]TestDummy{"c": {Foo: "fizz"}}, values)
+}
+
+func TestMemoryCache_Codec(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[[]byte](WithCodec[[]byte](BytesCodec{}))
+
+	assertions.NoError(memoryCache.Put("testMap", "raw", []byte("not json")))
+	assertions.Equal([]byte("not json"), memoryCache.maps["testMap"]["raw"].value)
+
+	value, err := memoryCache.Get("testMap", "raw")
+	assertions.NoError(err)
+	assertions.Equal([]byte("not json"), *value)
+
+	values, err := memoryCache.GetQuery("testMap", predicate.Equal("__key", "raw"))
+	assertions.NoError(err)
+	assertions.Equal([][]byte{[]byte("not json")}, values)
+
+	values, err = memoryCache.GetQuery("testMap", predicate.Equal("foo", "bar"))
+	assertions.NoError(err)
+	assertions.Empty(values)
+
+	memoryCache.maps["testMap"]["corrupt"] = &memoryEntry{value: serialization.JSON(`{}`)}
+
+	_, err = memoryCache.Get("testMap", "corrupt")
+	assertions.ErrorContains(err, "could not decode cached object with key 'corrupt'")
+}
+
+func TestMemoryCache_Errors(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy](WithNotFoundError[TestDummy]())
+
+	_, err := memoryCache.Get("testMap", "missing")
+	assertions.ErrorIs(err, ErrNotFound)
+
+	assertions.NoError(memoryCache.PutAll("testMap", map[string]TestDummy{"a": {Foo: "bar"}, "b": {Foo: "baz"}}))
+	memoryCache.maps["testMap"]["raw"] = &memoryEntry{value: []byte("not json")}
+	memoryCache.maps["testMap"]["mismatch"] = &memoryEntry{value: serialization.JSON(`{"foo": 1}`)}
+
+	var decodeErr *DecodeError
+	_, err = memoryCache.Get("testMap", "raw")
+	assertions.ErrorIs(err, ErrDecode)
+	assertions.ErrorIs(err, ErrNotJSON)
+	assertions.ErrorAs(err, &decodeErr)
+	assertions.Equal(DecodeError{MapName: "testMap", Key: "raw", Err: ErrNotJSON}, *decodeErr)
+
+	var typeErr *json.UnmarshalTypeError
+	_, err = memoryCache.Get("testMap", "mismatch")
+	assertions.ErrorIs(err, ErrDecode)
+	assertions.ErrorAs(err, &typeErr)
+
+	_, err = memoryCache.GetQuery("testMap", predicate.True())
+	assertions.ErrorIs(err, ErrDecode)
+
+	t.Run("skip corrupt query entries", func(t *testing.T) {
+		var skipped []string
+		reportingCache := NewMemoryCache[TestDummy](WithDecodeErrorHandler[TestDummy](func(err *DecodeError) {
+			skipped = append(skipped, err.Key)
+		}))
+		reportingCache.maps = memoryCache.maps
+
+		values, err := reportingCache.GetQuery("testMap", predicate.True())
+		assertions.NoError(err)
+		assertions.ElementsMatch([]TestDummy{{Foo: "bar"}, {Foo: "baz"}}, values)
+		assertions.ElementsMatch([]string{"raw", "mismatch"}, skipped)
+	})
+
+	t.Run("skip corrupt entries of pages", func(t *testing.T) {
+		var skipped []string
+		reportingCache := NewMemoryCache[TestDummy](WithDecodeErrorHandler[TestDummy](func(err *DecodeError) {
+			skipped = append(skipped, err.Key)
+		}))
+		reportingCache.maps = memoryCache.maps
+
+		_, err := memoryCache.GetQueryPage("testMap", predicate.True(), 0, 10)
+		assertions.ErrorIs(err, ErrDecode)
+
+		page, err := reportingCache.GetQueryPage("testMap", predicate.True(), 0, 10)
+		assertions.NoError(err)
+		assertions.Equal([]Entry[TestDummy]{{Key: "a", Value: TestDummy{Foo: "bar"}}, {Key: "b", Value: TestDummy{Foo: "baz"}}}, page.Entries)
+		assertions.ElementsMatch([]string{"raw", "mismatch"}, skipped)
+
+		seq, iterErr := reportingCache.QueryIterCtx(context.Background(), "testMap", predicate.True(), 1)
+		var keys []string
+		for key := range seq {
+			keys = append(keys, key)
+		}
+		assertions.NoError(iterErr())
+		assertions.Equal([]string{"a", "b"}, keys)
+
+		var buffer bytes.Buffer
+		exported, err := Export[TestDummy](context.Background(), reportingCache, "testMap", &buffer, nil)
+		assertions.NoError(err)
+		assertions.Equal(2, exported)
+	})
+}
+
+func TestMemoryCache_CanceledContext(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assertions.ErrorIs(memoryCache.PutCtx(ctx, "testMap", "dummy", TestDummy{Foo: "bar"}), context.Canceled)
+
+	_, err := memoryCache.GetCtx(ctx, "testMap", "dummy")
+	assertions.ErrorIs(err, context.Canceled)
+
+	_, err = memoryCache.GetQueryCtx(ctx, "testMap", predicate.True())
+	assertions.ErrorIs(err, context.Canceled)
+
+	assertions.ErrorIs(memoryCache.DeleteCtx(ctx, "testMap", "dummy"), context.Canceled)
+
+	_, err = memoryCache.AddListenerCtx(ctx, "testMap", &MockListener[TestDummy]{})
+	assertions.ErrorIs(err, context.Canceled)
+}
+
+func TestMemoryCache_GetQuery(t *testing.T) {
+	memoryCache := NewMemoryCache[TestDummy]()
+	for key, foo := range map[string]string{"a": "bar", "b": "baz", "c": "fizz"} {
+		assert.NoError(t, memoryCache.Put("testMap", key, TestDummy{Foo: foo}))
+	}
+
+	tests := []struct {
+		name     string
+		query    predicate.Predicate
+		expected []string
+	}{
+		{"equal", predicate.Equal("foo", "bar"), []string{"bar"}},
+		{"not equal", predicate.NotEqual("foo", "bar"), []string{"baz", "fizz"}},
+		{"in", predicate.In("foo", "bar", "fizz"), []string{"bar", "fizz"}},
+		{"like", predicate.Like("foo", "ba_"), []string{"bar", "baz"}},
+		{"ilike", predicate.ILike("foo", "FI%"), []string{"fizz"}},
+		{"regex", predicate.Regex("foo", "f.*"), []string{"fizz"}},
+		{"greater", predicate.Greater("foo", "baz"), []string{"fizz"}},
+		{"between", predicate.Between("foo", "bar", "baz"), []string{"bar", "baz"}},
+		{"key", predicate.Equal("__key", "c"), []string{"fizz"}},
+		{"and", predicate.And(predicate.Like("foo", "b%"), predicate.Not(predicate.Equal("foo", "bar"))), []string{"baz"}},
+		{"or", predicate.Or(predicate.Equal("foo", "bar"), predicate.Equal("foo", "fizz")), []string{"bar", "fizz"}},
+		{"true", predicate.True(), []string{"bar", "baz", "fizz"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertions := assert.New(t)
+
+			results, err := memoryCache.GetQuery("testMap", tt.query)
+			assertions.NoError(err)
+
+			foos := make([]string, 0, len(results))
+			for _, result := range results {
+				foos = append(foos, result.Foo)
+			}
+			assertions.Equal(tt.expected, foos)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := memoryCache.GetQuery("testMap", predicate.SQL("foo = 'bar'"))
+		assert.ErrorIs(t, err, ErrUnsupportedPredicate)
+	})
+}
+
+func TestMemoryCache_GetQueryEntries(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	assertions.NoError(memoryCache.PutAll("testMap", map[string]TestDummy{
+		"a": {Foo: "bar"},
+		"b": {Foo: "baz"},
+		"c": {Foo: "bar"},
+	}))
+
+	entries, err := memoryCache.GetQueryEntries("testMap", predicate.Equal("foo", "bar"))
+	assertions.NoError(err)
+	assertions.Equal([]Entry[TestDummy]{{Key: "a", Value: TestDummy{Foo: "bar"}}, {Key: "c", Value: TestDummy{Foo: "bar"}}}, entries)
+
+	keys, err := memoryCache.GetQueryKeys("testMap", predicate.Equal("foo", "bar"))
+	assertions.NoError(err)
+	assertions.Equal([]string{"a", "c"}, keys)
+}
+
+func TestMemoryCache_GetQueryPage(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	assertions.NoError(memoryCache.PutAll("testMap", map[string]TestDummy{
+		"a": {Foo: "bar"},
+		"b": {Foo: "bar"},
+		"c": {Foo: "baz"},
+		"d": {Foo: "bar"},
+	}))
+
+	page, err := memoryCache.GetQueryPage("testMap", predicate.Equal("foo", "bar"), 0, 2)
+	assertions.NoError(err)
+	assertions.Equal(3, page.TotalCount)
+	assertions.True(page.HasNext())
+	assertions.Equal([]Entry[TestDummy]{{Key: "a", Value: TestDummy{Foo: "bar"}}, {Key: "b", Value: TestDummy{Foo: "bar"}}}, page.Entries)
+
+	page, err = memoryCache.GetQueryPage("testMap", predicate.Equal("foo", "bar"), 1, 2)
+	assertions.NoError(err)
+	assertions.False(page.HasNext())
+	assertions.Equal([]Entry[TestDummy]{{Key: "d", Value: TestDummy{Foo: "bar"}}}, page.Entries)
+
+	page, err = memoryCache.GetQueryPage("testMap", predicate.Equal("foo", "bar"), 2, 2)
+	assertions.NoError(err)
+	assertions.Empty(page.Entries)
+
+	_, err = memoryCache.GetQueryPage("testMap", predicate.True(), 0, 0)
+	assertions.Error(err)
+}
+
+func TestMemoryCache_QueryIter(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	assertions.NoError(memoryCache.PutAll("testMap", map[string]TestDummy{
+		"a": {Foo: "bar"},
+		"b": {Foo: "bar"},
+		"c": {Foo: "baz"},
+		"d": {Foo: "bar"},
+	}))
+
+	keys := make([]string, 0)
+	for key, value := range memoryCache.QueryIter("testMap", predicate.Equal("foo", "bar")) {
+		assertions.Equal("bar", value.Foo)
+		keys = append(keys, key)
+	}
+	assertions.Equal([]string{"a", "b", "d"}, keys)
+
+	t.Run("break", func(t *testing.T) {
+		seq, errFn := memoryCache.QueryIterCtx(context.Background(), "testMap", predicate.True(), 1)
+
+		count := 0
+		for range seq {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, count)
+		assert.NoError(t, errFn())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		seq, errFn := memoryCache.QueryIterCtx(ctx, "testMap", predicate.True(), 1)
+
+		count := 0
+		for range seq {
+			count++
+			cancel()
+		}
+		assert.Equal(t, 1, count)
+		assert.ErrorIs(t, errFn(), context.Canceled)
+	})
+}
+
+func TestMemoryCache_GetQueryNested(t *testing.T) {
+	type nested struct {
+		Name  string   `json:"name"`
+		Count int      `json:"count"`
+		Tags  []string `json:"tags"`
+		Inner struct {
+			Enabled bool `json:"enabled"`
+		} `json:"inner"`
+	}
+
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[nested]()
+
+	first := nested{Name: "first", Count: 1, Tags: []string{"a", "b"}}
+	first.Inner.Enabled = true
+	second := nested{Name: "second", Count: 5, Tags: []string{"c"}}
+
+	assertions.NoError(memoryCache.Put("testMap", "first", first))
+	assertions.NoError(memoryCache.Put("testMap", "second", second))
+
+	results, err := memoryCache.GetQuery("testMap", predicate.GreaterOrEqual("count", 3))
+	assertions.NoError(err)
+	assertions.Len(results, 1)
+	assertions.Equal("second", results[0].Name)
+
+	results, err = memoryCache.GetQuery("testMap", predicate.Equal("inner.enabled", true))
+	assertions.NoError(err)
+	assertions.Len(results, 1)
+	assertions.Equal("first", results[0].Name)
+
+	results, err = memoryCache.GetQuery("testMap", predicate.Equal("tags[any]", "c"))
+	assertions.NoError(err)
+	assertions.Len(results, 1)
+	assertions.Equal("second", results[0].Name)
+
+	results, err = memoryCache.GetQuery("testMap", predicate.Equal("tags[1]", "b"))
+	assertions.NoError(err)
+	assertions.Len(results, 1)
+	assertions.Equal("first", results[0].Name)
+}
+
+func TestMemoryCache_AddListener(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	defer memoryCache.Close()
+
+	listener := &MockListener[TestDummy]{}
+	_, err := memoryCache.AddListener("testMap", listener)
+	assertions.NoError(err)
+
+	listenerDummy := TestDummy{Foo: "bar"}
+	assertions.NoError(memoryCache.Put("testMap", "listenerDummy", listenerDummy))
+	assertions.Eventually(func() bool {
+		return listener.onAddCalled.Load()
+	}, time.Second, 10*time.Millisecond)
+
+	listenerDummy.Foo = "fizz"
+	assertions.NoError(memoryCache.Put("testMap", "listenerDummy", listenerDummy))
+	assertions.Eventually(func() bool {
+		return listener.onUpdateCalled.Load()
+	}, time.Second, 10*time.Millisecond)
+
+	assertions.NoError(memoryCache.Delete("testMap", "listenerDummy"))
+	assertions.Eventually(func() bool {
+		return listener.onDeleteCalled.Load()
+	}, time.Second, 10*time.Millisecond)
+
+	assertions.False(listener.onErrorCalled.Load())
+	assertions.NoError(listener.lastError())
+}
+
+func TestMemoryCache_RemoveListener(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	defer memoryCache.Close()
+
+	removedListener := &MockListener[TestDummy]{}
+	handle, err := memoryCache.AddListener("testMap", removedListener)
+	assertions.NoError(err)
+	assertions.Equal("testMap", handle.MapName)
+	assertions.NotEmpty(handle.ID)
+
+	listener := &MockListener[TestDummy]{}
+	_, err = memoryCache.AddListener("testMap", listener)
+	assertions.NoError(err)
+
+	assertions.NoError(memoryCache.RemoveListener(handle))
+	assertions.NoError(memoryCache.RemoveListener(handle))
+
+	assertions.NoError(memoryCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+	assertions.Eventually(func() bool {
+		return listener.onAddCalled.Load()
+	}, time.Second, 10*time.Millisecond)
+	assertions.Empty(removedListener.receivedKeys())
+}
+
+func TestMemoryCache_FilteredListeners(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	defer memoryCache.Close()
+
+	keyListener := &MockListener[TestDummy]{}
+	_, err := memoryCache.AddListenerForKey("testMap", "a", keyListener)
+	assertions.NoError(err)
+
+	predicateListener := &MockListener[TestDummy]{}
+	_, err = memoryCache.AddListenerWithPredicate("testMap", predicate.Equal("foo", "bar"), predicateListener)
+	assertions.NoError(err)
+
+	keysOnlyListener := &MockListener[TestDummy]{}
+	_, err = memoryCache.AddListener("testMap", keysOnlyListener, WithoutValues())
+	assertions.NoError(err)
+
+	assertions.NoError(memoryCache.Put("testMap", "a", TestDummy{Foo: "baz"}))
+	assertions.NoError(memoryCache.Put("testMap", "b", TestDummy{Foo: "bar"}))
+	assertions.NoError(memoryCache.Delete("testMap", "b"))
+
+	assertions.Eventually(func() bool {
+		return len(keysOnlyListener.receivedKeys()) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	assertions.Equal([]string{"a"}, keyListener.receivedKeys())
+	assertions.Equal([]string{"b", "b"}, predicateListener.receivedKeys())
+	assertions.Equal([]string{"a", "b", "b"}, keysOnlyListener.receivedKeys())
+	assertions.Equal(make([]TestDummy, 3), keysOnlyListener.receivedValues())
+	assertions.False(keysOnlyListener.onErrorCalled.Load())
+}
+
+func TestMemoryCache_ExtendedListener(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	defer memoryCache.Close()
+
+	now := time.Now()
+	memoryCache.now = func() time.Time { return now }
+
+	listener := &ExtendedMockListener{}
+	_, err := memoryCache.AddListener("testMap", listener)
+	assertions.NoError(err)
+
+	assertions.NoError(memoryCache.Put("testMap", "deleted", TestDummy{Foo: "deleted"}))
+	assertions.NoError(memoryCache.Put("testMap", "evicted", TestDummy{Foo: "evicted"}))
+	assertions.NoError(memoryCache.PutWithTTL("testMap", "expired", TestDummy{Foo: "expired"}, time.Minute))
+	assertions.NoError(memoryCache.Put("testMap", "cleared", TestDummy{Foo: "cleared"}))
+
+	assertions.NoError(memoryCache.Delete("testMap", "deleted"))
+	memoryCache.Evict("testMap", "evicted")
+
+	now = now.Add(time.Minute)
+	_, err = memoryCache.Get("testMap", "expired")
+	assertions.NoError(err)
+
+	memoryCache.Clear("testMap")
+
+	assertions.Eventually(func() bool {
+		deleted, evicted, expired, cleared := listener.removals()
+		return deleted == 1 && evicted == 1 && expired == 1 && cleared == 1
+	}, time.Second, 10*time.Millisecond)
+
+	assertions.Equal(&TestDummy{Foo: "deleted"}, listener.deleted[0])
+	assertions.Equal(&TestDummy{Foo: "evicted"}, listener.evicted[0])
+	assertions.Equal(&TestDummy{Foo: "expired"}, listener.expired[0])
+	assertions.Equal([]int{1}, listener.cleared)
+	assertions.False(listener.onDeleteCalled.Load())
+}
+
+func TestMemoryCache_EventListener(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+	defer memoryCache.Close()
+
+	listener := &MockEventListener{}
+	_, err := memoryCache.AddListener("testMap", NewEventListenerAdapter[TestDummy](listener))
+	assertions.NoError(err)
+
+	assertions.NoError(memoryCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+	assertions.NoError(memoryCache.Put("testMap", "dummy", TestDummy{Foo: "baz"}))
+	assertions.NoError(memoryCache.Delete("testMap", "dummy"))
+	memoryCache.Clear("testMap")
+
+	expected := []EventKind{EventKindAdded, EventKindUpdated, EventKindRemoved, EventKindCleared}
+	assertions.Eventually(func() bool {
+		return len(listener.receivedKinds()) == len(expected)
+	}, time.Second, 10*time.Millisecond)
+	assertions.Equal(expected, listener.receivedKinds())
+
+	event := listener.events[0]
+	assertions.Equal("testMap", event.MapName)
+	assertions.Equal("dummy", event.Key)
+	assertions.Empty(event.Source)
+	assertions.False(event.Time.IsZero())
+}
+
+func TestMemoryCache_ConditionalWrites(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+
+	existing, err := memoryCache.PutIfAbsent("testMap", "dummy", TestDummy{Foo: "bar"})
+	assertions.NoError(err)
+	assertions.Nil(existing)
+
+	existing, err = memoryCache.PutIfAbsent("testMap", "dummy", TestDummy{Foo: "baz"})
+	assertions.NoError(err)
+	assertions.Equal(&TestDummy{Foo: "bar"}, existing)
+
+	replaced, err := memoryCache.Replace("testMap", "dummy", TestDummy{Foo: "baz"}, TestDummy{Foo: "fizz"})
+	assertions.NoError(err)
+	assertions.False(replaced)
+
+	replaced, err = memoryCache.Replace("testMap", "dummy", TestDummy{Foo: "bar"}, TestDummy{Foo: "fizz"})
+	assertions.NoError(err)
+	assertions.True(replaced)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := memoryCache.Update("testMap", "counter", func(old *TestDummy) (TestDummy, error) {
+				if old == nil {
+					return TestDummy{Foo: "x"}, nil
+				}
+				return TestDummy{Foo: old.Foo + "x"}, nil
+			})
+			assertions.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	counter, err := memoryCache.Get("testMap", "counter")
+	assertions.NoError(err)
+	assertions.Equal("xxxxx", counter.Foo)
+
+	_, err = memoryCache.Update("testMap", "counter", func(old *TestDummy) (TestDummy, error) {
+		assertions.NoError(memoryCache.Put("testMap", "counter", TestDummy{Foo: old.Foo + "y"}))
+		return *old, nil
+	})
+	assertions.ErrorIs(err, ErrUpdateConflict)
+}
+
+func TestMemoryCache_Lock(t *testing.T) {
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[TestDummy]()
+
+	owner := NewLockContext(context.Background())
+	other := NewLockContext(context.Background())
+
+	assertions.NoError(memoryCache.LockCtx(owner, "testMap", "dummy", 0))
+	assertions.NoError(memoryCache.LockCtx(owner, "testMap", "dummy", 0))
+
+	locked, err := memoryCache.TryLockCtx(other, "testMap", "dummy", 10*time.Millisecond, 0)
+	assertions.NoError(err)
+	assertions.False(locked)
+	assertions.ErrorIs(memoryCache.UnlockCtx(other, "testMap", "dummy"), ErrLockNotHeld)
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- memoryCache.LockCtx(other, "testMap", "dummy", 50*time.Millisecond)
+	}()
+
+	assertions.NoError(memoryCache.UnlockCtx(owner, "testMap", "dummy"))
+	assertions.Never(func() bool {
+		return len(acquired) > 0
+	}, 50*time.Millisecond, 10*time.Millisecond)
+
+	assertions.NoError(memoryCache.UnlockCtx(owner, "testMap", "dummy"))
+	assertions.NoError(<-acquired)
+
+	locked, err = memoryCache.TryLockCtx(owner, "testMap", "dummy", time.Second, 0)
+	assertions.NoError(err)
+	assertions.True(locked, "lock should be released once the lease expired")
+	assertions.NoError(memoryCache.UnlockCtx(owner, "testMap", "dummy"))
+
+	ctx, cancel := context.WithCancel(owner)
+	assertions.NoError(memoryCache.LockCtx(other, "testMap", "dummy", 0))
+	cancel()
+	assertions.ErrorIs(memoryCache.LockCtx(ctx, "testMap", "dummy", 0), context.Canceled)
+}
+
+func TestMemoryCache_EntryProcessors(t *testing.T) {
+	type counter struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	assertions := assert.New(t)
+	memoryCache := NewMemoryCache[counter]()
+
+	assertions.NoError(memoryCache.PutAll("testMap", map[string]counter{
+		"a": {Name: "first", Count: 1},
+		"b": {Name: "second", Count: 2},
+		"c": {Name: "first", Count: 3},
+	}))
+
+	patched, err := memoryCache.Patch("testMap", "a", PatchIncrement("/count", 1))
+	assertions.NoError(err)
+	assertions.Equal(&counter{Name: "first", Count: 2}, patched)
+
+	patched, err = memoryCache.Patch("testMap", "missing", PatchIncrement("/count", 1))
+	assertions.NoError(err)
+	assertions.Nil(patched)
+
+	patchedEntries, err := memoryCache.PatchEntries("testMap", predicate.Equal("name", "first"), PatchReplace("/count", 0))
+	assertions.NoError(err)
+	assertions.Equal(map[string]counter{"a": {Name: "first"}, "c": {Name: "first"}}, patchedEntries)
+
+	results, err := memoryCache.ExecuteOnKeys("testMap", []string{"b", "missing"}, NewJSONPatchProcessor(PatchReplace("/name", "third")))
+	assertions.NoError(err)
+	assertions.Equal(map[string]any{"b": serialization.JSON(`{"count":2,"name":"third"}`)}, results)
+
+	_, err = memoryCache.ExecuteOnKey("testMap", "a", NewJSONPatchProcessor(PatchTest("/count", 5)))
+	assertions.ErrorIs(err, ErrPatchTestFailed)
+
+	_, err = memoryCache.ExecuteOnKey("testMap", "a", &remoteProcessor{})
+	assertions.ErrorIs(err, ErrProcessorNotSupported)
+}
+
+// remoteProcessor is an entry processor that can only be executed by Hazelcast.
+type remoteProcessor struct{}
+
+func (p *remoteProcessor) FactoryID() int32 { return 1 }
+
+func (p *remoteProcessor) ClassID() int32 { return 1 }
+
+func (p *remoteProcessor) WriteData(_ serialization.DataOutput) {}
+
+func (p *remoteProcessor) ReadData(_ serialization.DataInput) {}
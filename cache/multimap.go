@@ -0,0 +1,484 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/hazelcast/hazelcast-go-client"
+)
+
+// MultiMap stores a collection of values per key, e.g. the IDs of all subscriptions for an event type.
+// Values are compared by their encoded form. Put returns false if the value is already stored for the key,
+// which is the behaviour of the default SET value collection of Hazelcast.
+// The Hazelcast Go client does not support listeners on multi-maps, so MultiMap does not offer them either.
+type MultiMap[T any] interface {
+	Put(mapName string, key string, value T) (bool, error)
+	PutCtx(ctx context.Context, mapName string, key string, value T) (bool, error)
+	PutAll(mapName string, key string, values []T) error
+	PutAllCtx(ctx context.Context, mapName string, key string, values []T) error
+	Get(mapName string, key string) ([]T, error)
+	GetCtx(ctx context.Context, mapName string, key string) ([]T, error)
+	Contains(mapName string, key string, value T) (bool, error)
+	ContainsCtx(ctx context.Context, mapName string, key string, value T) (bool, error)
+	ValueCount(mapName string, key string) (int, error)
+	ValueCountCtx(ctx context.Context, mapName string, key string) (int, error)
+	Remove(mapName string, key string, value T) (bool, error)
+	RemoveCtx(ctx context.Context, mapName string, key string, value T) (bool, error)
+	Delete(mapName string, key string) error
+	DeleteCtx(ctx context.Context, mapName string, key string) error
+	Keys(mapName string) ([]string, error)
+	KeysCtx(ctx context.Context, mapName string) ([]string, error)
+	Size(mapName string) (int, error)
+	SizeCtx(ctx context.Context, mapName string) (int, error)
+	Clear(mapName string) error
+	ClearCtx(ctx context.Context, mapName string) error
+}
+
+var (
+	_ MultiMap[any] = (*HazelcastMultiMap[any])(nil)
+	_ MultiMap[any] = (*MemoryMultiMap[any])(nil)
+)
+
+// HazelcastMultiMap is a MultiMap backed by Hazelcast multi-maps.
+type HazelcastMultiMap[T any] struct {
+	ctx    context.Context
+	client *hazelcast.Client
+	codec  Codec[T]
+	opts   options[T]
+}
+
+// NewHazelcastMultiMap creates a MultiMap using the given client, e.g. the one of a HazelcastCache.
+func NewHazelcastMultiMap[T any](client *hazelcast.Client, opts ...Option[T]) *HazelcastMultiMap[T] {
+	o := newOptions(opts)
+	return &HazelcastMultiMap[T]{ctx: context.Background(), client: client, codec: o.codec, opts: o}
+}
+
+func (m *HazelcastMultiMap[T]) Put(mapName string, key string, value T) (bool, error) {
+	return m.PutCtx(m.ctx, mapName, key, value)
+}
+
+func (m *HazelcastMultiMap[T]) PutCtx(ctx context.Context, mapName string, key string, value T) (bool, error) {
+	mp, encoded, err := m.prepare(ctx, mapName, key, value)
+	if err != nil {
+		return false, err
+	}
+
+	return mp.Put(ctx, key, encoded)
+}
+
+func (m *HazelcastMultiMap[T]) PutAll(mapName string, key string, values []T) error {
+	return m.PutAllCtx(m.ctx, mapName, key, values)
+}
+
+func (m *HazelcastMultiMap[T]) PutAllCtx(ctx context.Context, mapName string, key string, values []T) error {
+	encoded, err := encodeAll(m.codec, key, values)
+	if err != nil {
+		return err
+	}
+
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	return mp.PutAll(ctx, key, encoded...)
+}
+
+func (m *HazelcastMultiMap[T]) Get(mapName string, key string) ([]T, error) {
+	return m.GetCtx(m.ctx, mapName, key)
+}
+
+func (m *HazelcastMultiMap[T]) GetCtx(ctx context.Context, mapName string, key string) ([]T, error) {
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := mp.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeAll(&m.opts, mapName, key, values)
+}
+
+func (m *HazelcastMultiMap[T]) Contains(mapName string, key string, value T) (bool, error) {
+	return m.ContainsCtx(m.ctx, mapName, key, value)
+}
+
+func (m *HazelcastMultiMap[T]) ContainsCtx(ctx context.Context, mapName string, key string, value T) (bool, error) {
+	mp, encoded, err := m.prepare(ctx, mapName, key, value)
+	if err != nil {
+		return false, err
+	}
+
+	return mp.ContainsEntry(ctx, key, encoded)
+}
+
+func (m *HazelcastMultiMap[T]) ValueCount(mapName string, key string) (int, error) {
+	return m.ValueCountCtx(m.ctx, mapName, key)
+}
+
+func (m *HazelcastMultiMap[T]) ValueCountCtx(ctx context.Context, mapName string, key string) (int, error) {
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return 0, err
+	}
+
+	return mp.ValueCount(ctx, key)
+}
+
+func (m *HazelcastMultiMap[T]) Remove(mapName string, key string, value T) (bool, error) {
+	return m.RemoveCtx(m.ctx, mapName, key, value)
+}
+
+func (m *HazelcastMultiMap[T]) RemoveCtx(ctx context.Context, mapName string, key string, value T) (bool, error) {
+	mp, encoded, err := m.prepare(ctx, mapName, key, value)
+	if err != nil {
+		return false, err
+	}
+
+	return mp.RemoveEntry(ctx, key, encoded)
+}
+
+func (m *HazelcastMultiMap[T]) Delete(mapName string, key string) error {
+	return m.DeleteCtx(m.ctx, mapName, key)
+}
+
+func (m *HazelcastMultiMap[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	return mp.Delete(ctx, key)
+}
+
+func (m *HazelcastMultiMap[T]) Keys(mapName string) ([]string, error) {
+	return m.KeysCtx(m.ctx, mapName)
+}
+
+func (m *HazelcastMultiMap[T]) KeysCtx(ctx context.Context, mapName string) ([]string, error) {
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := mp.GetKeySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStringSlice(keys), nil
+}
+
+func (m *HazelcastMultiMap[T]) Size(mapName string) (int, error) {
+	return m.SizeCtx(m.ctx, mapName)
+}
+
+func (m *HazelcastMultiMap[T]) SizeCtx(ctx context.Context, mapName string) (int, error) {
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return 0, err
+	}
+
+	return mp.Size(ctx)
+}
+
+func (m *HazelcastMultiMap[T]) Clear(mapName string) error {
+	return m.ClearCtx(m.ctx, mapName)
+}
+
+func (m *HazelcastMultiMap[T]) ClearCtx(ctx context.Context, mapName string) error {
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	return mp.Clear(ctx)
+}
+
+func (m *HazelcastMultiMap[T]) prepare(ctx context.Context, mapName string, key string, value T) (*hazelcast.MultiMap, any, error) {
+	encoded, err := m.codec.Encode(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode value with key '%s': %w", key, err)
+	}
+
+	mp, err := m.client.GetMultiMap(ctx, mapName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mp, encoded, nil
+}
+
+// MemoryMultiMap is an in-process implementation of MultiMap with the same encoding as HazelcastMultiMap.
+type MemoryMultiMap[T any] struct {
+	mu    sync.RWMutex
+	maps  map[string]map[string][]any
+	codec Codec[T]
+	opts  options[T]
+}
+
+// NewMemoryMultiMap creates a new empty MemoryMultiMap.
+func NewMemoryMultiMap[T any](opts ...Option[T]) *MemoryMultiMap[T] {
+	o := newOptions(opts)
+	return &MemoryMultiMap[T]{maps: make(map[string]map[string][]any), codec: o.codec, opts: o}
+}
+
+func (m *MemoryMultiMap[T]) Put(mapName string, key string, value T) (bool, error) {
+	return m.PutCtx(context.Background(), mapName, key, value)
+}
+
+func (m *MemoryMultiMap[T]) PutCtx(ctx context.Context, mapName string, key string, value T) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	encoded, err := encodeAll(m.codec, key, []T{value})
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.add(mapName, key, encoded[0]), nil
+}
+
+func (m *MemoryMultiMap[T]) PutAll(mapName string, key string, values []T) error {
+	return m.PutAllCtx(context.Background(), mapName, key, values)
+}
+
+func (m *MemoryMultiMap[T]) PutAllCtx(ctx context.Context, mapName string, key string, values []T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	encoded, err := encodeAll(m.codec, key, values)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, value := range encoded {
+		m.add(mapName, key, value)
+	}
+
+	return nil
+}
+
+func (m *MemoryMultiMap[T]) Get(mapName string, key string) ([]T, error) {
+	return m.GetCtx(context.Background(), mapName, key)
+}
+
+func (m *MemoryMultiMap[T]) GetCtx(ctx context.Context, mapName string, key string) ([]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	values := slices.Clone(m.maps[mapName][key])
+	m.mu.RUnlock()
+
+	return decodeAll(&m.opts, mapName, key, values)
+}
+
+func (m *MemoryMultiMap[T]) Contains(mapName string, key string, value T) (bool, error) {
+	return m.ContainsCtx(context.Background(), mapName, key, value)
+}
+
+func (m *MemoryMultiMap[T]) ContainsCtx(ctx context.Context, mapName string, key string, value T) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	encoded, err := encodeAll(m.codec, key, []T{value})
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.index(mapName, key, encoded[0]) >= 0, nil
+}
+
+func (m *MemoryMultiMap[T]) ValueCount(mapName string, key string) (int, error) {
+	return m.ValueCountCtx(context.Background(), mapName, key)
+}
+
+func (m *MemoryMultiMap[T]) ValueCountCtx(ctx context.Context, mapName string, key string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.maps[mapName][key]), nil
+}
+
+func (m *MemoryMultiMap[T]) Remove(mapName string, key string, value T) (bool, error) {
+	return m.RemoveCtx(context.Background(), mapName, key, value)
+}
+
+func (m *MemoryMultiMap[T]) RemoveCtx(ctx context.Context, mapName string, key string, value T) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	encoded, err := encodeAll(m.codec, key, []T{value})
+	if err != nil {
+		return false, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.index(mapName, key, encoded[0])
+	if i < 0 {
+		return false, nil
+	}
+
+	values := slices.Delete(m.maps[mapName][key], i, i+1)
+	if len(values) == 0 {
+		delete(m.maps[mapName], key)
+	} else {
+		m.maps[mapName][key] = values
+	}
+
+	return true, nil
+}
+
+func (m *MemoryMultiMap[T]) Delete(mapName string, key string) error {
+	return m.DeleteCtx(context.Background(), mapName, key)
+}
+
+func (m *MemoryMultiMap[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.maps[mapName], key)
+
+	return nil
+}
+
+func (m *MemoryMultiMap[T]) Keys(mapName string) ([]string, error) {
+	return m.KeysCtx(context.Background(), mapName)
+}
+
+func (m *MemoryMultiMap[T]) KeysCtx(ctx context.Context, mapName string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return slices.Sorted(maps.Keys(m.maps[mapName])), nil
+}
+
+func (m *MemoryMultiMap[T]) Size(mapName string) (int, error) {
+	return m.SizeCtx(context.Background(), mapName)
+}
+
+func (m *MemoryMultiMap[T]) SizeCtx(ctx context.Context, mapName string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var size int
+	for _, values := range m.maps[mapName] {
+		size += len(values)
+	}
+
+	return size, nil
+}
+
+func (m *MemoryMultiMap[T]) Clear(mapName string) error {
+	return m.ClearCtx(context.Background(), mapName)
+}
+
+func (m *MemoryMultiMap[T]) ClearCtx(ctx context.Context, mapName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.maps, mapName)
+
+	return nil
+}
+
+// add stores an encoded value unless it is already stored for the key. It must be called while holding the write lock.
+func (m *MemoryMultiMap[T]) add(mapName string, key string, value any) bool {
+	if m.index(mapName, key, value) >= 0 {
+		return false
+	}
+
+	mp, ok := m.maps[mapName]
+	if !ok {
+		mp = make(map[string][]any)
+		m.maps[mapName] = mp
+	}
+
+	mp[key] = append(mp[key], value)
+
+	return true
+}
+
+// index returns the position of an encoded value of the given key or -1. It must be called while holding the lock.
+func (m *MemoryMultiMap[T]) index(mapName string, key string, value any) int {
+	return slices.IndexFunc(m.maps[mapName][key], func(stored any) bool {
+		return reflect.DeepEqual(stored, value)
+	})
+}
+
+func encodeAll[T any](codec Codec[T], key string, values []T) ([]any, error) {
+	encoded := make([]any, 0, len(values))
+	for _, value := range values {
+		encodedValue, err := codec.Encode(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode value with key '%s': %w", key, err)
+		}
+		encoded = append(encoded, encodedValue)
+	}
+
+	return encoded, nil
+}
+
+// decodeAll decodes the values of a key. Values that cannot be decoded fail the read unless a decode error handler is set.
+func decodeAll[T any](opts *options[T], mapName string, key string, values []any) ([]T, error) {
+	decoded := make([]T, 0, len(values))
+	for _, value := range values {
+		decodedValue, err := decode(opts.codec, mapName, key, value)
+		if opts.skipDecodeError(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, decodedValue)
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryMultiMap(t *testing.T) {
+	testMultiMap(t, NewMemoryMultiMap[TestDummy]())
+}
+
+func TestMemoryMultiMap_DecodeErrors(t *testing.T) {
+	testMultiMapDecodeErrors(t,
+		func(opts ...Option[TestDummy]) MultiMap[TestDummy] {
+			return NewMemoryMultiMap(opts...)
+		},
+		func(multiMap MultiMap[TestDummy], key string) {
+			memoryMap := multiMap.(*MemoryMultiMap[TestDummy])
+			memoryMap.maps["errorMultiMap"][key] = append(memoryMap.maps["errorMultiMap"][key], serialization.JSON(`{"foo": 1}`))
+		},
+	)
+}
+
+func testMultiMap(t *testing.T, multiMap MultiMap[TestDummy]) {
+	t.Helper()
+	assertions := assert.New(t)
+
+	stored, err := multiMap.Put("testMultiMap", "a", TestDummy{Foo: "bar"})
+	assertions.NoError(err)
+	assertions.True(stored)
+
+	stored, err = multiMap.Put("testMultiMap", "a", TestDummy{Foo: "bar"})
+	assertions.NoError(err)
+	assertions.False(stored)
+
+	assertions.NoError(multiMap.PutAll("testMultiMap", "a", []TestDummy{{Foo: "baz"}, {Foo: "fizz"}}))
+	assertions.NoError(multiMap.PutAll("testMultiMap", "b", []TestDummy{{Foo: "buzz"}}))
+
+	values, err := multiMap.Get("testMultiMap", "a")
+	assertions.NoError(err)
+	assertions.ElementsMatch([]TestDummy{{Foo: "bar"}, {Foo: "baz"}, {Foo: "fizz"}}, values)
+
+	contains, err := multiMap.Contains("testMultiMap", "a", TestDummy{Foo: "baz"})
+	assertions.NoError(err)
+	assertions.True(contains)
+
+	count, err := multiMap.ValueCount("testMultiMap", "a")
+	assertions.NoError(err)
+	assertions.Equal(3, count)
+
+	size, err := multiMap.Size("testMultiMap")
+	assertions.NoError(err)
+	assertions.Equal(4, size)
+
+	keys, err := multiMap.Keys("testMultiMap")
+	assertions.NoError(err)
+	assertions.ElementsMatch([]string{"a", "b"}, keys)
+
+	removed, err := multiMap.Remove("testMultiMap", "a", TestDummy{Foo: "baz"})
+	assertions.NoError(err)
+	assertions.True(removed)
+
+	removed, err = multiMap.Remove("testMultiMap", "a", TestDummy{Foo: "baz"})
+	assertions.NoError(err)
+	assertions.False(removed)
+
+	assertions.NoError(multiMap.Delete("testMultiMap", "b"))
+	values, err = multiMap.Get("testMultiMap", "b")
+	assertions.NoError(err)
+	assertions.Empty(values)
+
+	assertions.NoError(multiMap.Clear("testMultiMap"))
+	size, err = multiMap.Size("testMultiMap")
+	assertions.NoError(err)
+	assertions.Zero(size)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = multiMap.PutCtx(ctx, "testMultiMap", "a", TestDummy{Foo: "bar"})
+	assertions.ErrorIs(err, context.Canceled)
+}
+
+// testMultiMapDecodeErrors checks the handling of corrupt values. corrupt adds a value that cannot be decoded
+// to a key of the map "errorMultiMap" after it has been populated.
+func testMultiMapDecodeErrors(
+	t *testing.T,
+	newMultiMap func(opts ...Option[TestDummy]) MultiMap[TestDummy],
+	corrupt func(multiMap MultiMap[TestDummy], key string),
+) {
+	t.Helper()
+	assertions := assert.New(t)
+
+	var skipped []string
+	multiMaps := []MultiMap[TestDummy]{
+		newMultiMap(),
+		newMultiMap(WithDecodeErrorHandler[TestDummy](func(err *DecodeError) {
+			skipped = append(skipped, err.Key)
+		})),
+	}
+	for _, multiMap := range multiMaps {
+		_, err := multiMap.Put("errorMultiMap", "a", TestDummy{Foo: "bar"})
+		assertions.NoError(err)
+		corrupt(multiMap, "a")
+	}
+
+	plain, skipping := multiMaps[0], multiMaps[1]
+
+	_, err := plain.Get("errorMultiMap", "a")
+	assertions.ErrorIs(err, ErrDecode)
+
+	values, err := skipping.Get("errorMultiMap", "a")
+	assertions.NoError(err)
+	assertions.Equal([]TestDummy{{Foo: "bar"}}, values)
+	assertions.Equal([]string{"a"}, skipped)
+
+	for _, multiMap := range multiMaps {
+		assertions.NoError(multiMap.Clear("errorMultiMap"))
+	}
+}
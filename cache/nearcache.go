@@ -0,0 +1,450 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+)
+
+// DefaultNearCacheMaxEntries is the number of entries a NearCache keeps unless configured otherwise.
+const DefaultNearCacheMaxEntries = 10000
+
+var errPreloadNotSupported = errors.New("preloading requires a queryable cache")
+
+// NearCache keeps a bounded local copy of the values read from another Cache.
+// Entries are invalidated by an entry listener on the underlying map, which is registered the first time a map
+// is read. Writes through the NearCache invalidate the local copy as well. The least recently used entries are
+// dropped once the maximum number of entries is reached, and entries are dropped after the configured TTL.
+// Values are copied on read, but values containing pointers, maps or slices still share their contents.
+type NearCache[T any] struct {
+	Cache[T]
+
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	entries  map[nearCacheKey]*list.Element
+	lru      *list.List
+	versions map[string]uint64
+	stats    NearCacheStats
+
+	listenerMu sync.Mutex
+	handles    map[string]ListenerHandle
+}
+
+// NearCacheStats contains the statistics of a NearCache.
+type NearCacheStats struct {
+	Hits          uint64
+	Misses        uint64
+	Evictions     uint64
+	Invalidations uint64
+	Entries       int
+}
+
+var _ Cache[any] = (*NearCache[any])(nil)
+
+type nearCacheKey struct {
+	mapName string
+	key     string
+}
+
+type nearCacheEntry[T any] struct {
+	key       nearCacheKey
+	value     T
+	expiresAt time.Time
+}
+
+// NearCacheOption configures a NearCache.
+type NearCacheOption func(*nearCacheOptions)
+
+type nearCacheOptions struct {
+	maxEntries int
+	ttl        time.Duration
+}
+
+// WithMaxEntries limits the number of entries kept by a NearCache.
+func WithMaxEntries(maxEntries int) NearCacheOption {
+	return func(o *nearCacheOptions) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// WithNearCacheTTL sets the time after which local entries are dropped, even if they have not been invalidated.
+func WithNearCacheTTL(ttl time.Duration) NearCacheOption {
+	return func(o *nearCacheOptions) {
+		o.ttl = ttl
+	}
+}
+
+// NewNearCache creates a NearCache in front of the given cache.
+func NewNearCache[T any](backend Cache[T], opts ...NearCacheOption) *NearCache[T] {
+	o := nearCacheOptions{maxEntries: DefaultNearCacheMaxEntries}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &NearCache[T]{
+		Cache:      backend,
+		maxEntries: o.maxEntries,
+		ttl:        o.ttl,
+		now:        time.Now,
+		entries:    make(map[nearCacheKey]*list.Element),
+		lru:        list.New(),
+		versions:   make(map[string]uint64),
+		handles:    make(map[string]ListenerHandle),
+	}
+}
+
+// Preload registers the invalidation listener for the map and loads all of its entries, at most up to the
+// maximum number of entries. The underlying cache must be a QueryableCache.
+func (c *NearCache[T]) Preload(ctx context.Context, mapName string) error {
+	queryable, ok := c.Cache.(QueryableCache[T])
+	if !ok {
+		return errPreloadNotSupported
+	}
+
+	if err := c.watch(ctx, mapName); err != nil {
+		return err
+	}
+
+	version := c.version(mapName)
+	seq, errFn := queryable.QueryIterCtx(ctx, mapName, predicate.True(), DefaultQueryPageSize)
+
+	loaded := 0
+	for key, value := range seq {
+		if loaded >= c.maxEntries {
+			break
+		}
+
+		c.store(nearCacheKey{mapName, key}, value, version)
+		loaded++
+	}
+
+	return errFn()
+}
+
+// Stats returns a snapshot of the statistics of the NearCache.
+func (c *NearCache[T]) Stats() NearCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Close removes the invalidation listeners and drops all local entries.
+func (c *NearCache[T]) Close(ctx context.Context) error {
+	c.listenerMu.Lock()
+	defer c.listenerMu.Unlock()
+
+	var errs []error
+	for mapName, handle := range c.handles {
+		if err := c.Cache.RemoveListenerCtx(ctx, handle); err != nil {
+			errs = append(errs, err)
+		}
+		delete(c.handles, mapName)
+	}
+
+	c.mu.Lock()
+	clear(c.entries)
+	c.lru.Init()
+	c.mu.Unlock()
+
+	return errors.Join(errs...)
+}
+
+func (c *NearCache[T]) Get(mapName string, key string) (*T, error) {
+	return c.GetCtx(context.Background(), mapName, key)
+}
+
+func (c *NearCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	if value, ok := c.lookup(nearCacheKey{mapName, key}); ok {
+		return &value, nil
+	}
+
+	if err := c.watch(ctx, mapName); err != nil {
+		return nil, err
+	}
+
+	version := c.version(mapName)
+	value, err := c.Cache.GetCtx(ctx, mapName, key)
+	if err != nil || value == nil {
+		return value, err
+	}
+
+	c.store(nearCacheKey{mapName, key}, *value, version)
+	return value, nil
+}
+
+func (c *NearCache[T]) GetAll(mapName string, keys []string) (map[string]T, error) {
+	return c.GetAllCtx(context.Background(), mapName, keys)
+}
+
+func (c *NearCache[T]) GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	values := make(map[string]T, len(keys))
+	missing := make([]string, 0)
+	for _, key := range keys {
+		if value, ok := c.lookup(nearCacheKey{mapName, key}); ok {
+			values[key] = value
+		} else {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	if err := c.watch(ctx, mapName); err != nil {
+		return nil, err
+	}
+
+	version := c.version(mapName)
+	fetched, err := c.Cache.GetAllCtx(ctx, mapName, missing)
+	for key, value := range fetched {
+		c.store(nearCacheKey{mapName, key}, value, version)
+		values[key] = value
+	}
+
+	return values, err
+}
+
+func (c *NearCache[T]) Put(mapName string, key string, value T) error {
+	return c.PutCtx(context.Background(), mapName, key, value)
+}
+
+func (c *NearCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	defer c.invalidate(mapName, key)
+	return c.Cache.PutCtx(ctx, mapName, key, value)
+}
+
+func (c *NearCache[T]) PutWithTTL(mapName string, key string, value T, ttl time.Duration) error {
+	return c.PutWithTTLCtx(context.Background(), mapName, key, value, ttl)
+}
+
+func (c *NearCache[T]) PutWithTTLCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration) error {
+	defer c.invalidate(mapName, key)
+	return c.Cache.PutWithTTLCtx(ctx, mapName, key, value, ttl)
+}
+
+func (c *NearCache[T]) PutWithTTLAndMaxIdle(mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error {
+	return c.PutWithTTLAndMaxIdleCtx(context.Background(), mapName, key, value, ttl, maxIdle)
+}
+
+func (c *NearCache[T]) PutWithTTLAndMaxIdleCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	value T,
+	ttl time.Duration,
+	maxIdle time.Duration,
+) error {
+	defer c.invalidate(mapName, key)
+	return c.Cache.PutWithTTLAndMaxIdleCtx(ctx, mapName, key, value, ttl, maxIdle)
+}
+
+func (c *NearCache[T]) Delete(mapName string, key string) error {
+	return c.DeleteCtx(context.Background(), mapName, key)
+}
+
+func (c *NearCache[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	defer c.invalidate(mapName, key)
+	return c.Cache.DeleteCtx(ctx, mapName, key)
+}
+
+func (c *NearCache[T]) PutAll(mapName string, values map[string]T) error {
+	return c.PutAllCtx(context.Background(), mapName, values)
+}
+
+func (c *NearCache[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	defer func() {
+		for key := range values {
+			c.invalidate(mapName, key)
+		}
+	}()
+	return c.Cache.PutAllCtx(ctx, mapName, values)
+}
+
+func (c *NearCache[T]) DeleteAll(mapName string, keys []string) error {
+	return c.DeleteAllCtx(context.Background(), mapName, keys)
+}
+
+func (c *NearCache[T]) DeleteAllCtx(ctx context.Context, mapName string, keys []string) error {
+	defer func() {
+		for _, key := range keys {
+			c.invalidate(mapName, key)
+		}
+	}()
+	return c.Cache.DeleteAllCtx(ctx, mapName, keys)
+}
+
+// watch registers the invalidation listener for the map if it has not been registered yet.
+func (c *NearCache[T]) watch(ctx context.Context, mapName string) error {
+	c.listenerMu.Lock()
+	defer c.listenerMu.Unlock()
+
+	if _, ok := c.handles[mapName]; ok {
+		return nil
+	}
+
+	handle, err := c.Cache.AddListenerCtx(ctx, mapName, &nearCacheInvalidator[T]{cache: c, mapName: mapName}, WithoutValues())
+	if err != nil {
+		return err
+	}
+
+	c.handles[mapName] = handle
+	return nil
+}
+
+// lookup returns a copy of the local value and records a hit or miss.
+func (c *NearCache[T]) lookup(key nearCacheKey) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		c.stats.Misses++
+		var zero T
+		return zero, false
+	}
+
+	entry := element.Value.(*nearCacheEntry[T])
+	if !entry.expiresAt.IsZero() && !c.now().Before(entry.expiresAt) {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+		c.stats.Misses++
+		var zero T
+		return zero, false
+	}
+
+	c.lru.MoveToFront(element)
+	c.stats.Hits++
+	return entry.value, true
+}
+
+// store keeps a value locally unless the map has been invalidated since the value was requested.
+func (c *NearCache[T]) store(key nearCacheKey, value T, version uint64) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.versions[key.mapName] != version {
+		return
+	}
+
+	entry := &nearCacheEntry[T]{key: key, value: value}
+	if c.ttl > 0 {
+		entry.expiresAt = c.now().Add(c.ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*nearCacheEntry[T]).key)
+		c.stats.Evictions++
+	}
+}
+
+func (c *NearCache[T]) version(mapName string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.versions[mapName]
+}
+
+// invalidate drops the local copy of an entry. Values that are being fetched for the same map are not stored,
+// since they might have been read before the change.
+func (c *NearCache[T]) invalidate(mapName string, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[mapName]++
+	if element, ok := c.entries[nearCacheKey{mapName, key}]; ok {
+		c.lru.Remove(element)
+		delete(c.entries, nearCacheKey{mapName, key})
+		c.stats.Invalidations++
+	}
+}
+
+// invalidateMap drops the local copies of all entries of a map.
+func (c *NearCache[T]) invalidateMap(mapName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[mapName]++
+	for key, element := range c.entries {
+		if key.mapName == mapName {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+			c.stats.Invalidations++
+		}
+	}
+}
+
+// nearCacheInvalidator is the listener a NearCache registers to invalidate entries changed elsewhere.
+type nearCacheInvalidator[T any] struct {
+	cache   *NearCache[T]
+	mapName string
+}
+
+func (l *nearCacheInvalidator[T]) OnAdd(event *hazelcast.EntryNotified, _ T) {
+	l.invalidate(event)
+}
+
+func (l *nearCacheInvalidator[T]) OnUpdate(event *hazelcast.EntryNotified, _ T, _ T) {
+	l.invalidate(event)
+}
+
+func (l *nearCacheInvalidator[T]) OnDelete(event *hazelcast.EntryNotified) {
+	l.invalidate(event)
+}
+
+func (l *nearCacheInvalidator[T]) OnDeleteWithValue(event *hazelcast.EntryNotified, _ *T) {
+	l.invalidate(event)
+}
+
+func (l *nearCacheInvalidator[T]) OnEvict(event *hazelcast.EntryNotified, _ *T) {
+	l.invalidate(event)
+}
+
+func (l *nearCacheInvalidator[T]) OnExpire(event *hazelcast.EntryNotified, _ *T) {
+	l.invalidate(event)
+}
+
+func (l *nearCacheInvalidator[T]) OnClear(_ *hazelcast.EntryNotified) {
+	l.cache.invalidateMap(l.mapName)
+}
+
+func (l *nearCacheInvalidator[T]) OnError(event *hazelcast.EntryNotified, _ error) {
+	l.invalidate(event)
+}
+
+func (l *nearCacheInvalidator[T]) invalidate(event *hazelcast.EntryNotified) {
+	key, ok := event.Key.(string)
+	if !ok {
+		l.cache.invalidateMap(l.mapName)
+		return
+	}
+
+	l.cache.invalidate(l.mapName, key)
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearCache_Get(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	nearCache := NewNearCache[TestDummy](backend)
+	defer nearCache.Close(context.Background())
+
+	assertions.NoError(backend.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+
+	for range 3 {
+		dummy, err := nearCache.Get("testMap", "dummy")
+		assertions.NoError(err)
+		assertions.Equal("bar", dummy.Foo)
+	}
+
+	missing, err := nearCache.Get("testMap", "missing")
+	assertions.NoError(err)
+	assertions.Nil(missing)
+
+	stats := nearCache.Stats()
+	assertions.Equal(uint64(2), stats.Hits)
+	assertions.Equal(uint64(2), stats.Misses)
+	assertions.Equal(1, stats.Entries)
+}
+
+func TestNearCache_Invalidation(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	nearCache := NewNearCache[TestDummy](backend)
+	defer nearCache.Close(context.Background())
+
+	assertions.NoError(nearCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+
+	dummy, err := nearCache.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Equal("bar", dummy.Foo)
+
+	assertions.NoError(backend.Put("testMap", "dummy", TestDummy{Foo: "baz"}))
+	assertions.Eventually(func() bool {
+		dummy, err := nearCache.Get("testMap", "dummy")
+		return err == nil && dummy.Foo == "baz"
+	}, time.Second, 10*time.Millisecond)
+
+	assertions.NoError(nearCache.Delete("testMap", "dummy"))
+	dummy, err = nearCache.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Nil(dummy)
+
+	assertions.NoError(backend.Put("testMap", "dummy", TestDummy{Foo: "fizz"}))
+	values, err := nearCache.GetAll("testMap", []string{"dummy"})
+	assertions.NoError(err)
+	assertions.Equal(map[string]TestDummy{"dummy": {Foo: "fizz"}}, values)
+
+	backend.Clear("testMap")
+	assertions.Eventually(func() bool {
+		return nearCache.Stats().Entries == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNearCache_Limits(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	nearCache := NewNearCache[TestDummy](backend, WithMaxEntries(2), WithNearCacheTTL(time.Minute))
+	defer nearCache.Close(context.Background())
+
+	now := time.Now()
+	nearCache.now = func() time.Time { return now }
+
+	assertions.NoError(backend.PutAll("testMap", map[string]TestDummy{
+		"a": {Foo: "bar"},
+		"b": {Foo: "baz"},
+		"c": {Foo: "fizz"},
+	}))
+
+	for _, key := range []string{"a", "b", "a", "c"} {
+		_, err := nearCache.Get("testMap", key)
+		assertions.NoError(err)
+	}
+
+	stats := nearCache.Stats()
+	assertions.Equal(2, stats.Entries)
+	assertions.Equal(uint64(1), stats.Evictions)
+	assertions.Contains(nearCache.entries, nearCacheKey{"testMap", "a"})
+	assertions.NotContains(nearCache.entries, nearCacheKey{"testMap", "b"})
+
+	now = now.Add(time.Minute)
+	_, err := nearCache.Get("testMap", "a")
+	assertions.NoError(err)
+	assertions.Equal(uint64(1), nearCache.Stats().Hits)
+}
+
+func TestNearCache_Preload(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	nearCache := NewNearCache[TestDummy](backend)
+	defer nearCache.Close(context.Background())
+
+	assertions.NoError(backend.PutAll("testMap", map[string]TestDummy{
+		"a": {Foo: "bar"},
+		"b": {Foo: "baz"},
+	}))
+
+	assertions.NoError(nearCache.Preload(context.Background(), "testMap"))
+	assertions.Equal(2, nearCache.Stats().Entries)
+
+	_, err := nearCache.Get("testMap", "b")
+	assertions.NoError(err)
+	assertions.Equal(uint64(1), nearCache.Stats().Hits)
+
+	assertions.ErrorIs(NewNearCache[TestDummy](nearCache).Preload(context.Background(), "testMap"), errPreloadNotSupported)
+}
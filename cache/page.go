@@ -0,0 +1,137 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+)
+
+// DefaultQueryPageSize is the number of entries fetched at once by QueryIter.
+const DefaultQueryPageSize = 1000
+
+var errInvalidPageSize = errors.New("page size must be greater than zero")
+
+// Page is a single page of query results. Entries are ordered by their key.
+type Page[T any] struct {
+	Entries    []Entry[T]
+	Number     int
+	Size       int
+	TotalCount int
+}
+
+// HasNext reports whether there are more pages after this one.
+func (p *Page[T]) HasNext() bool {
+	return (p.Number+1)*p.Size < p.TotalCount
+}
+
+// pagingCache provides the operations paging is built upon.
+// The Hazelcast Go client does not support paging predicates, so the keys of all matching entries
+// are loaded at once and sorted, while the values are fetched page by page using bulk gets.
+type pagingCache[T any] interface {
+	GetQueryKeysCtx(ctx context.Context, mapName string, query predicate.Predicate) ([]string, error)
+	GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error)
+}
+
+func queryPage[T any](
+	ctx context.Context,
+	c pagingCache[T],
+	mapName string,
+	query predicate.Predicate,
+	page int,
+	pageSize int,
+) (*Page[T], error) {
+	if pageSize <= 0 {
+		return nil, errInvalidPageSize
+	}
+
+	if page < 0 {
+		return nil, fmt.Errorf("page must not be negative, got %d", page)
+	}
+
+	keys, err := c.GetQueryKeysCtx(ctx, mapName, query)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(keys)
+
+	result := &Page[T]{Entries: make([]Entry[T], 0), Number: page, Size: pageSize, TotalCount: len(keys)}
+
+	start := page * pageSize
+	if start >= len(keys) {
+		return result, nil
+	}
+
+	pageKeys := keys[start:min(start+pageSize, len(keys))]
+	values, err := c.GetAllCtx(ctx, mapName, pageKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Entries = orderedEntries(pageKeys, values)
+	return result, nil
+}
+
+func queryIter[T any](
+	ctx context.Context,
+	c pagingCache[T],
+	mapName string,
+	query predicate.Predicate,
+	pageSize int,
+) (iter.Seq2[string, T], func() error) {
+	var iterErr error
+
+	seq := func(yield func(string, T) bool) {
+		iterErr = nil
+		if pageSize <= 0 {
+			iterErr = errInvalidPageSize
+			return
+		}
+
+		keys, err := c.GetQueryKeysCtx(ctx, mapName, query)
+		if err != nil {
+			iterErr = err
+			return
+		}
+		slices.Sort(keys)
+
+		for pageKeys := range slices.Chunk(keys, pageSize) {
+			if err := ctx.Err(); err != nil {
+				iterErr = err
+				return
+			}
+
+			values, err := c.GetAllCtx(ctx, mapName, pageKeys)
+			if err != nil {
+				iterErr = err
+				return
+			}
+
+			for _, entry := range orderedEntries(pageKeys, values) {
+				if !yield(entry.Key, entry.Value) {
+					return
+				}
+			}
+		}
+	}
+
+	return seq, func() error { return iterErr }
+}
+
+// orderedEntries returns the values in the order of the given keys, skipping keys that have been removed in the meantime.
+func orderedEntries[T any](keys []string, values map[string]T) []Entry[T] {
+	entries := make([]Entry[T], 0, len(values))
+	for _, key := range keys {
+		if value, ok := values[key]; ok {
+			entries = append(entries, Entry[T]{Key: key, Value: value})
+		}
+	}
+	return entries
+}
@@ -0,0 +1,534 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// PersistentCacheOption configures a PersistentCache.
+type PersistentCacheOption func(*persistentCacheOptions)
+
+type persistentCacheOptions struct {
+	writeBehind   bool
+	flushInterval time.Duration
+	batchSize     int
+	retries       int
+	backoff       time.Duration
+	errorHandler  func(mapName string, key string, err error)
+}
+
+// Defaults of a PersistentCache.
+const (
+	DefaultStoreRetries         = 3
+	DefaultStoreRetryBackoff    = 100 * time.Millisecond
+	DefaultWriteBehindBatchSize = 100
+	DefaultWriteBehindInterval  = time.Second
+)
+
+// WithWriteBehind defers writes to the store, which are flushed every interval or once batchSize keys are pending.
+// Values of zero use DefaultWriteBehindInterval and DefaultWriteBehindBatchSize.
+func WithWriteBehind(interval time.Duration, batchSize int) PersistentCacheOption {
+	return func(o *persistentCacheOptions) {
+		o.writeBehind = true
+		if interval > 0 {
+			o.flushInterval = interval
+		}
+		if batchSize > 0 {
+			o.batchSize = batchSize
+		}
+	}
+}
+
+// WithStoreRetries sets how often a failed store operation is retried. The backoff doubles after every attempt.
+func WithStoreRetries(retries int, backoff time.Duration) PersistentCacheOption {
+	return func(o *persistentCacheOptions) {
+		o.retries = max(retries, 0)
+		o.backoff = backoff
+	}
+}
+
+// WithStoreErrorHandler sets a function that is called for every write-behind operation that failed after all retries.
+func WithStoreErrorHandler(handler func(mapName string, key string, err error)) PersistentCacheOption {
+	return func(o *persistentCacheOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// PersistentCache keeps a cache consistent with a Store.
+//
+// Values missing in the cache are loaded from the store and written to the cache (read-through).
+// By default, writes go to the store first and only reach the cache if they succeeded (write-through).
+// If the cache fails after the store has been written, the entries are removed from the cache, so that they are
+// read through from the store again.
+// With WithWriteBehind, writes reach the cache immediately and the store asynchronously. Pending writes of a key
+// are coalesced, and a pending delete prevents reading the deleted value through from the store.
+// Writes that fail after all retries are passed to the error handler and dropped.
+// Close flushes all pending writes and has to be called before shutting down. Writes after Close go through to the store.
+type PersistentCache[T any] struct {
+	Cache[T]
+	store Store[T]
+	opts  persistentCacheOptions
+
+	mu       sync.Mutex
+	pending  map[pendingKey]pendingWrite[T]
+	inflight map[pendingKey]pendingWrite[T]
+	versions map[string]uint64
+	flushMu  sync.Mutex
+	closeMu  sync.RWMutex
+	closed   bool
+
+	flushSignal chan struct{}
+	stop        chan struct{}
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+type pendingKey struct {
+	mapName string
+	key     string
+}
+
+type pendingWrite[T any] struct {
+	value   T
+	deleted bool
+}
+
+// NewPersistentCache decorates a cache with a store.
+func NewPersistentCache[T any](c Cache[T], store Store[T], opts ...PersistentCacheOption) *PersistentCache[T] {
+	o := persistentCacheOptions{
+		flushInterval: DefaultWriteBehindInterval,
+		batchSize:     DefaultWriteBehindBatchSize,
+		retries:       DefaultStoreRetries,
+		backoff:       DefaultStoreRetryBackoff,
+		errorHandler:  func(string, string, error) {},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := &PersistentCache[T]{
+		Cache:       c,
+		store:       store,
+		opts:        o,
+		pending:     make(map[pendingKey]pendingWrite[T]),
+		versions:    make(map[string]uint64),
+		flushSignal: make(chan struct{}, 1),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	if o.writeBehind {
+		go p.run()
+	} else {
+		close(p.done)
+	}
+
+	return p
+}
+
+func (p *PersistentCache[T]) Put(mapName string, key string, value T) error {
+	return p.PutCtx(context.Background(), mapName, key, value)
+}
+
+func (p *PersistentCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	return p.write(ctx, mapName, map[string]T{key: value}, func() error {
+		return p.Cache.PutCtx(ctx, mapName, key, value)
+	})
+}
+
+func (p *PersistentCache[T]) PutWithTTL(mapName string, key string, value T, ttl time.Duration) error {
+	return p.PutWithTTLCtx(context.Background(), mapName, key, value, ttl)
+}
+
+func (p *PersistentCache[T]) PutWithTTLCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration) error {
+	return p.write(ctx, mapName, map[string]T{key: value}, func() error {
+		return p.Cache.PutWithTTLCtx(ctx, mapName, key, value, ttl)
+	})
+}
+
+func (p *PersistentCache[T]) PutWithTTLAndMaxIdle(mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error {
+	return p.PutWithTTLAndMaxIdleCtx(context.Background(), mapName, key, value, ttl, maxIdle)
+}
+
+func (p *PersistentCache[T]) PutWithTTLAndMaxIdleCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	value T,
+	ttl time.Duration,
+	maxIdle time.Duration,
+) error {
+	return p.write(ctx, mapName, map[string]T{key: value}, func() error {
+		return p.Cache.PutWithTTLAndMaxIdleCtx(ctx, mapName, key, value, ttl, maxIdle)
+	})
+}
+
+func (p *PersistentCache[T]) PutAll(mapName string, values map[string]T) error {
+	return p.PutAllCtx(context.Background(), mapName, values)
+}
+
+func (p *PersistentCache[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	return p.write(ctx, mapName, values, func() error {
+		return p.Cache.PutAllCtx(ctx, mapName, values)
+	})
+}
+
+func (p *PersistentCache[T]) Delete(mapName string, key string) error {
+	return p.DeleteCtx(context.Background(), mapName, key)
+}
+
+func (p *PersistentCache[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	return p.remove(ctx, mapName, []string{key}, func() error {
+		return p.Cache.DeleteCtx(ctx, mapName, key)
+	})
+}
+
+func (p *PersistentCache[T]) DeleteAll(mapName string, keys []string) error {
+	return p.DeleteAllCtx(context.Background(), mapName, keys)
+}
+
+func (p *PersistentCache[T]) DeleteAllCtx(ctx context.Context, mapName string, keys []string) error {
+	return p.remove(ctx, mapName, keys, func() error {
+		return p.Cache.DeleteAllCtx(ctx, mapName, keys)
+	})
+}
+
+func (p *PersistentCache[T]) Get(mapName string, key string) (*T, error) {
+	return p.GetCtx(context.Background(), mapName, key)
+}
+
+// GetCtx returns the value of the cache or loads it from the store. If the cache reports a miss as ErrNotFound,
+// the error is returned if the store does not contain the value either.
+func (p *PersistentCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	version := p.version(mapName)
+	value, err := p.Cache.GetCtx(ctx, mapName, key)
+	notFound := errors.Is(err, ErrNotFound)
+	if err != nil && !notFound {
+		return nil, err
+	}
+
+	if value != nil {
+		return value, nil
+	}
+
+	if !p.deletionPending(mapName, key) {
+		loaded, loadErr := p.store.Load(ctx, mapName, key)
+		if loadErr != nil {
+			return nil, fmt.Errorf("failed to load key '%s' of map '%s' from store: %w", key, mapName, loadErr)
+		}
+
+		if loaded != nil {
+			values := map[string]T{key: *loaded}
+			if err := p.writeBack(ctx, mapName, values, version); err != nil {
+				return nil, err
+			}
+
+			value := values[key]
+			return &value, nil
+		}
+	}
+
+	if notFound {
+		return nil, err
+	}
+
+	//nolint:nilnil // Cache miss is a valid state, not an error; also comply with api
+	return nil, nil
+}
+
+func (p *PersistentCache[T]) GetAll(mapName string, keys []string) (map[string]T, error) {
+	return p.GetAllCtx(context.Background(), mapName, keys)
+}
+
+// GetAllCtx returns the values of the cache and loads the missing ones from the store.
+func (p *PersistentCache[T]) GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	version := p.version(mapName)
+	values, err := p.Cache.GetAllCtx(ctx, mapName, keys)
+	if err != nil {
+		return values, err
+	}
+
+	missing := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := values[key]; !ok && !p.deletionPending(mapName, key) {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) == 0 {
+		return values, nil
+	}
+
+	loaded, err := p.store.LoadAll(ctx, mapName, missing)
+	if err != nil {
+		return values, fmt.Errorf("failed to load entries of map '%s' from store: %w", mapName, err)
+	}
+
+	if err := p.writeBack(ctx, mapName, loaded, version); err != nil {
+		return values, err
+	}
+
+	maps.Copy(values, loaded)
+	return values, nil
+}
+
+// writeBack writes values loaded from the store to the cache without overwriting newer values.
+// A ConcurrentCache only stores absent values and the existing ones replace the loaded ones. Other caches are skipped
+// if the map has been written through this cache since the given version, as the loaded values might be outdated.
+func (p *PersistentCache[T]) writeBack(ctx context.Context, mapName string, loaded map[string]T, version uint64) error {
+	if len(loaded) == 0 {
+		return nil
+	}
+
+	concurrentCache, ok := p.Cache.(ConcurrentCache[T])
+	if !ok {
+		if p.version(mapName) != version {
+			return nil
+		}
+		return p.Cache.PutAllCtx(ctx, mapName, loaded)
+	}
+
+	for key, value := range loaded {
+		existing, err := concurrentCache.PutIfAbsentCtx(ctx, mapName, key, value)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			loaded[key] = *existing
+		}
+	}
+
+	return nil
+}
+
+// Flush writes all pending writes to the store. It returns the writes that failed after all retries.
+func (p *PersistentCache[T]) Flush(ctx context.Context) error {
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+
+	p.mu.Lock()
+	p.inflight, p.pending = p.pending, make(map[pendingKey]pendingWrite[T])
+	inflight := p.inflight
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		p.inflight = nil
+		p.mu.Unlock()
+	}()
+
+	var errs []error
+	for key, write := range inflight {
+		err := p.retry(ctx, func() error {
+			if write.deleted {
+				return p.store.Delete(ctx, key.mapName, key.key)
+			}
+			return p.store.Store(ctx, key.mapName, key.key, write.value)
+		})
+
+		if err != nil {
+			p.opts.errorHandler(key.mapName, key.key, err)
+			errs = append(errs, fmt.Errorf("failed to write key '%s' of map '%s' to store: %w", key.key, key.mapName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close stops flushing in the background and flushes all pending writes. Later writes are written through.
+func (p *PersistentCache[T]) Close(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		p.closeMu.Lock()
+		p.closed = true
+		p.closeMu.Unlock()
+
+		if p.opts.writeBehind {
+			close(p.stop)
+		}
+	})
+	<-p.done
+
+	return p.Flush(ctx)
+}
+
+func (p *PersistentCache[T]) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.opts.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		case <-p.flushSignal:
+		}
+
+		// Failed writes are reported to the error handler by Flush.
+		_ = p.Flush(context.Background())
+	}
+}
+
+// write stores values in the store and the cache, or enqueues them when writing behind.
+func (p *PersistentCache[T]) write(ctx context.Context, mapName string, values map[string]T, cacheWrite func() error) error {
+	p.bumpVersion(mapName)
+
+	if release, ok := p.writingBehind(); ok {
+		defer release()
+
+		if err := cacheWrite(); err != nil {
+			return err
+		}
+
+		for key, value := range values {
+			p.enqueue(mapName, key, pendingWrite[T]{value: value})
+		}
+		return nil
+	}
+
+	for key, value := range values {
+		err := p.retry(ctx, func() error {
+			return p.store.Store(ctx, mapName, key, value)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write key '%s' of map '%s' to store: %w", key, mapName, err)
+		}
+	}
+
+	if err := cacheWrite(); err != nil {
+		return p.invalidate(ctx, mapName, slices.Collect(maps.Keys(values)), err)
+	}
+
+	return nil
+}
+
+// remove deletes keys from the store and the cache, or enqueues the deletions when writing behind.
+func (p *PersistentCache[T]) remove(ctx context.Context, mapName string, keys []string, cacheDelete func() error) error {
+	p.bumpVersion(mapName)
+
+	if release, ok := p.writingBehind(); ok {
+		defer release()
+
+		if err := cacheDelete(); err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			p.enqueue(mapName, key, pendingWrite[T]{deleted: true})
+		}
+		return nil
+	}
+
+	for _, key := range keys {
+		err := p.retry(ctx, func() error {
+			return p.store.Delete(ctx, mapName, key)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete key '%s' of map '%s' from store: %w", key, mapName, err)
+		}
+	}
+
+	if err := cacheDelete(); err != nil {
+		return p.invalidate(ctx, mapName, keys, err)
+	}
+
+	return nil
+}
+
+// invalidate removes entries from the cache after it failed to apply a change that has already been stored.
+// The returned error contains the cause and whether the entries could be removed.
+func (p *PersistentCache[T]) invalidate(ctx context.Context, mapName string, keys []string, cause error) error {
+	err := p.retry(ctx, func() error {
+		return p.Cache.DeleteAllCtx(ctx, mapName, keys)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update map '%s' in cache after updating the store, entries may be stale: %w",
+			mapName, errors.Join(cause, err))
+	}
+
+	return fmt.Errorf("failed to update map '%s' in cache after updating the store, entries have been removed: %w", mapName, cause)
+}
+
+// writingBehind reports whether writes are enqueued, which is the case until the cache is closed.
+// If so, release has to be called once the write has been enqueued, so that Close flushes it.
+func (p *PersistentCache[T]) writingBehind() (release func(), ok bool) {
+	if !p.opts.writeBehind {
+		return nil, false
+	}
+
+	p.closeMu.RLock()
+	if p.closed {
+		p.closeMu.RUnlock()
+		return nil, false
+	}
+
+	return p.closeMu.RUnlock, true
+}
+
+func (p *PersistentCache[T]) version(mapName string) uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.versions[mapName]
+}
+
+func (p *PersistentCache[T]) bumpVersion(mapName string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.versions[mapName]++
+}
+
+func (p *PersistentCache[T]) enqueue(mapName string, key string, write pendingWrite[T]) {
+	p.mu.Lock()
+	p.pending[pendingKey{mapName: mapName, key: key}] = write
+	full := len(p.pending) >= p.opts.batchSize
+	p.mu.Unlock()
+
+	if full {
+		select {
+		case p.flushSignal <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// deletionPending reports whether the latest pending write of a key deletes it.
+func (p *PersistentCache[T]) deletionPending(mapName string, key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := pendingKey{mapName: mapName, key: key}
+	if write, ok := p.pending[k]; ok {
+		return write.deleted
+	}
+
+	write, ok := p.inflight[k]
+	return ok && write.deleted
+}
+
+func (p *PersistentCache[T]) retry(ctx context.Context, fn func() error) error {
+	backoff := p.opts.backoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.opts.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
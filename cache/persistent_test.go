@@ -0,0 +1,365 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errStoreUnavailable = errors.New("store unavailable")
+	errCacheWrite       = errors.New("cache write failed")
+)
+
+// flakyStore fails the configured number of writes before delegating to a MemoryStore.
+type flakyStore[T any] struct {
+	*MemoryStore[T]
+	mu       sync.Mutex
+	failures int
+	writes   int
+}
+
+func (s *flakyStore[T]) Store(ctx context.Context, mapName string, key string, value T) error {
+	s.mu.Lock()
+	s.writes++
+	fail := s.failures > 0
+	if fail {
+		s.failures--
+	}
+	s.mu.Unlock()
+
+	if fail {
+		return errStoreUnavailable
+	}
+	return s.MemoryStore.Store(ctx, mapName, key, value)
+}
+
+func (s *flakyStore[T]) Writes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writes
+}
+
+func TestPersistentCache_ReadThrough(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	store := NewMemoryStore[TestDummy]()
+	assertions.NoError(store.Store(context.Background(), "testMap", "stored", TestDummy{Foo: "stored"}))
+
+	persistentCache := NewPersistentCache[TestDummy](backend, store)
+	defer persistentCache.Close(context.Background())
+
+	dummy, err := persistentCache.Get("testMap", "stored")
+	assertions.NoError(err)
+	assertions.Equal("stored", dummy.Foo)
+
+	cached, err := backend.Get("testMap", "stored")
+	assertions.NoError(err)
+	assertions.Equal("stored", cached.Foo)
+
+	missing, err := persistentCache.Get("testMap", "missing")
+	assertions.NoError(err)
+	assertions.Nil(missing)
+
+	assertions.NoError(backend.Put("testMap", "cached", TestDummy{Foo: "cached"}))
+	values, err := persistentCache.GetAll("testMap", []string{"stored", "cached", "missing"})
+	assertions.NoError(err)
+	assertions.Len(values, 2)
+	assertions.Equal("cached", values["cached"].Foo)
+}
+
+func TestPersistentCache_NotFound(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy](WithNotFoundError[TestDummy]())
+	defer backend.Close()
+
+	store := NewMemoryStore[TestDummy]()
+	assertions.NoError(store.Store(context.Background(), "testMap", "stored", TestDummy{Foo: "stored"}))
+
+	persistentCache := NewPersistentCache[TestDummy](backend, store)
+	defer persistentCache.Close(context.Background())
+
+	dummy, err := persistentCache.Get("testMap", "stored")
+	assertions.NoError(err)
+	assertions.Equal("stored", dummy.Foo)
+
+	_, err = persistentCache.Get("testMap", "missing")
+	assertions.ErrorIs(err, ErrNotFound)
+}
+
+// loadHookStore calls onLoad after loading values, e.g. to simulate a concurrent write.
+type loadHookStore[T any] struct {
+	*MemoryStore[T]
+	onLoad func()
+}
+
+func (s *loadHookStore[T]) Load(ctx context.Context, mapName string, key string) (*T, error) {
+	value, err := s.MemoryStore.Load(ctx, mapName, key)
+	s.loaded()
+	return value, err
+}
+
+func (s *loadHookStore[T]) LoadAll(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	values, err := s.MemoryStore.LoadAll(ctx, mapName, keys)
+	s.loaded()
+	return values, err
+}
+
+func (s *loadHookStore[T]) loaded() {
+	if s.onLoad != nil {
+		onLoad := s.onLoad
+		s.onLoad = nil
+		onLoad()
+	}
+}
+
+func TestPersistentCache_ReadThroughConcurrentWrite(t *testing.T) {
+	for name, wrap := range map[string]func(c *MemoryCache[TestDummy]) Cache[TestDummy]{
+		"concurrent cache": func(c *MemoryCache[TestDummy]) Cache[TestDummy] { return c },
+		"plain cache":      func(c *MemoryCache[TestDummy]) Cache[TestDummy] { return struct{ Cache[TestDummy] }{c} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			assertions := assert.New(t)
+			backend := NewMemoryCache[TestDummy]()
+			defer backend.Close()
+
+			store := &loadHookStore[TestDummy]{MemoryStore: NewMemoryStore[TestDummy]()}
+			assertions.NoError(store.Store(context.Background(), "testMap", "dummy", TestDummy{Foo: "old"}))
+
+			persistentCache := NewPersistentCache[TestDummy](wrap(backend), store)
+			defer persistentCache.Close(context.Background())
+
+			store.onLoad = func() {
+				assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "new"}))
+			}
+
+			_, err := persistentCache.Get("testMap", "dummy")
+			assertions.NoError(err)
+
+			cached, err := backend.Get("testMap", "dummy")
+			assertions.NoError(err)
+			assertions.Equal("new", cached.Foo)
+
+			assertions.NoError(backend.Delete("testMap", "dummy"))
+			store.onLoad = func() {
+				assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "newer"}))
+			}
+
+			_, err = persistentCache.GetAll("testMap", []string{"dummy"})
+			assertions.NoError(err)
+
+			cached, err = backend.Get("testMap", "dummy")
+			assertions.NoError(err)
+			assertions.Equal("newer", cached.Foo)
+		})
+	}
+}
+
+// failingWriteCache fails the configured number of writes and deletes before delegating to a MemoryCache.
+type failingWriteCache[T any] struct {
+	*MemoryCache[T]
+	writeFailures  atomic.Int64
+	deleteFailures atomic.Int64
+}
+
+func (c *failingWriteCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	if c.writeFailures.Add(-1) >= 0 {
+		return errCacheWrite
+	}
+	return c.MemoryCache.PutCtx(ctx, mapName, key, value)
+}
+
+func (c *failingWriteCache[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	if c.deleteFailures.Add(-1) >= 0 {
+		return errCacheWrite
+	}
+	return c.MemoryCache.DeleteCtx(ctx, mapName, key)
+}
+
+func TestPersistentCache_WriteThroughCacheFailure(t *testing.T) {
+	assertions := assert.New(t)
+	backend := &failingWriteCache[TestDummy]{MemoryCache: NewMemoryCache[TestDummy]()}
+	defer backend.Close()
+
+	store := NewMemoryStore[TestDummy]()
+	persistentCache := NewPersistentCache[TestDummy](backend, store, WithStoreRetries(1, time.Millisecond))
+	defer persistentCache.Close(context.Background())
+
+	assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "old"}))
+
+	backend.writeFailures.Store(1)
+	err := persistentCache.Put("testMap", "dummy", TestDummy{Foo: "new"})
+	assertions.ErrorIs(err, errCacheWrite)
+
+	cached, err := backend.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Nil(cached, "entries have to be removed from the cache if updating them failed")
+
+	dummy, err := persistentCache.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Equal("new", dummy.Foo)
+
+	backend.deleteFailures.Store(1)
+	err = persistentCache.Delete("testMap", "dummy")
+	assertions.ErrorIs(err, errCacheWrite)
+	assertions.Empty(store.Keys("testMap"))
+
+	cached, err = backend.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Nil(cached)
+}
+
+func TestPersistentCache_WriteThrough(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	store := &flakyStore[TestDummy]{MemoryStore: NewMemoryStore[TestDummy](), failures: 1}
+	persistentCache := NewPersistentCache[TestDummy](backend, store, WithStoreRetries(1, time.Millisecond))
+	defer persistentCache.Close(context.Background())
+
+	assertions.NoError(persistentCache.Put("testMap", "first", TestDummy{Foo: "first"}))
+	assertions.Equal(2, store.Writes())
+
+	stored, err := store.Load(context.Background(), "testMap", "first")
+	assertions.NoError(err)
+	assertions.Equal("first", stored.Foo)
+
+	assertions.NoError(persistentCache.PutAll("testMap", map[string]TestDummy{"second": {Foo: "second"}}))
+	assertions.Equal([]string{"first", "second"}, store.Keys("testMap"))
+
+	assertions.NoError(persistentCache.Delete("testMap", "first"))
+	assertions.Equal([]string{"second"}, store.Keys("testMap"))
+
+	cached, err := backend.Get("testMap", "first")
+	assertions.NoError(err)
+	assertions.Nil(cached)
+
+	t.Run("failed store write skips cache", func(t *testing.T) {
+		store.mu.Lock()
+		store.failures = 2
+		store.mu.Unlock()
+
+		err := persistentCache.Put("testMap", "failed", TestDummy{Foo: "failed"})
+		assertions.ErrorIs(err, errStoreUnavailable)
+
+		cached, err := backend.Get("testMap", "failed")
+		assertions.NoError(err)
+		assertions.Nil(cached)
+	})
+}
+
+func TestPersistentCache_WriteBehind(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	store := NewMemoryStore[TestDummy]()
+	assertions.NoError(store.Store(context.Background(), "testMap", "deleted", TestDummy{Foo: "deleted"}))
+
+	persistentCache := NewPersistentCache[TestDummy](backend, store, WithWriteBehind(time.Hour, 100))
+
+	assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "old"}))
+	assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "new"}))
+	assertions.NoError(persistentCache.Delete("testMap", "deleted"))
+
+	cached, err := backend.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Equal("new", cached.Foo)
+	assertions.Equal([]string{"deleted"}, store.Keys("testMap"))
+
+	deleted, err := persistentCache.Get("testMap", "deleted")
+	assertions.NoError(err)
+	assertions.Nil(deleted, "pending deletions must not be read through")
+
+	assertions.NoError(persistentCache.Close(context.Background()))
+	assertions.Equal([]string{"dummy"}, store.Keys("testMap"))
+
+	stored, err := store.Load(context.Background(), "testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Equal("new", stored.Foo)
+}
+
+func TestPersistentCache_WriteAfterClose(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	store := NewMemoryStore[TestDummy]()
+	persistentCache := NewPersistentCache[TestDummy](backend, store, WithWriteBehind(time.Hour, 100))
+
+	assertions.NoError(persistentCache.Put("testMap", "deleted", TestDummy{Foo: "deleted"}))
+	assertions.NoError(persistentCache.Close(context.Background()))
+
+	assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+	assertions.NoError(persistentCache.Delete("testMap", "deleted"))
+	assertions.Equal([]string{"dummy"}, store.Keys("testMap"))
+
+	cached, err := backend.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Equal("bar", cached.Foo)
+
+	assertions.NoError(persistentCache.Close(context.Background()))
+	assertions.Equal([]string{"dummy"}, store.Keys("testMap"))
+}
+
+func TestPersistentCache_WriteBehindBatch(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	store := NewMemoryStore[TestDummy]()
+	persistentCache := NewPersistentCache[TestDummy](backend, store, WithWriteBehind(time.Hour, 2))
+	defer persistentCache.Close(context.Background())
+
+	assertions.NoError(persistentCache.PutAll("testMap", map[string]TestDummy{
+		"first":  {Foo: "first"},
+		"second": {Foo: "second"},
+	}))
+
+	assertions.Eventually(func() bool {
+		return len(store.Keys("testMap")) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPersistentCache_WriteBehindErrors(t *testing.T) {
+	assertions := assert.New(t)
+	backend := NewMemoryCache[TestDummy]()
+	defer backend.Close()
+
+	var mu sync.Mutex
+	var failed []string
+
+	store := &flakyStore[TestDummy]{MemoryStore: NewMemoryStore[TestDummy](), failures: 3}
+	persistentCache := NewPersistentCache[TestDummy](backend, store,
+		WithWriteBehind(time.Hour, 100),
+		WithStoreRetries(2, time.Millisecond),
+		WithStoreErrorHandler(func(mapName string, key string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, mapName+"/"+key)
+		}),
+	)
+
+	assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+
+	err := persistentCache.Flush(context.Background())
+	assertions.ErrorIs(err, errStoreUnavailable)
+	assertions.Equal(3, store.Writes())
+	assertions.Equal([]string{"testMap/dummy"}, failed)
+
+	assertions.NoError(persistentCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+	assertions.NoError(persistentCache.Close(context.Background()))
+	assertions.Equal([]string{"dummy"}, store.Keys("testMap"))
+}
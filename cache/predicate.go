@@ -0,0 +1,387 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+// The factory and class ids used by the Hazelcast client for the built-in predicates that can be evaluated in-process.
+const (
+	predicateFactoryID = -20
+
+	predicateClassAnd         = 1
+	predicateClassBetween     = 2
+	predicateClassEqual       = 3
+	predicateClassGreaterLess = 4
+	predicateClassLike        = 5
+	predicateClassILike       = 6
+	predicateClassIn          = 7
+	predicateClassNotEqual    = 9
+	predicateClassNot         = 10
+	predicateClassOr          = 11
+	predicateClassRegex       = 12
+	predicateClassFalse       = 13
+	predicateClassTrue        = 14
+)
+
+const (
+	keyAttribute  = "__key"
+	thisAttribute = "this"
+)
+
+// ErrUnsupportedPredicate is returned if a predicate cannot be evaluated in-process, such as SQL predicates.
+var ErrUnsupportedPredicate = errors.New("predicate is not supported for in-memory evaluation")
+
+// EvaluatePredicate reports whether the entry with the given key and value matches the predicate.
+// The value is converted to JSON and evaluated in-process in the same way MemoryCache evaluates queries.
+func EvaluatePredicate(pred predicate.Predicate, key string, value any) (bool, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	var document any
+	if err := json.Unmarshal(bytes, &document); err != nil {
+		return false, err
+	}
+
+	return evaluatePredicate(pred, key, document)
+}
+
+// evaluatePredicate evaluates a Hazelcast predicate against a single entry in-process.
+// The value is expected to be the result of unmarshalling a JSON document into an any.
+// Since the predicates of the Hazelcast client do not expose their fields, they are
+// inspected by recording the data they write during serialization.
+func evaluatePredicate(pred predicate.Predicate, key string, value any) (bool, error) {
+	if pred == nil {
+		return true, nil
+	}
+
+	if pred.FactoryID() != predicateFactoryID {
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedPredicate, pred)
+	}
+
+	recorder := new(predicateRecorder)
+	pred.WriteData(recorder)
+	fields := recorder.fields
+
+	switch pred.ClassID() {
+	case predicateClassTrue:
+		return true, nil
+
+	case predicateClassFalse:
+		return false, nil
+
+	case predicateClassAnd, predicateClassOr:
+		return evaluateJunction(pred.ClassID() == predicateClassAnd, fields, key, value)
+
+	case predicateClassNot:
+		inner, ok := fields[0].(predicate.Predicate)
+		if !ok {
+			return false, fmt.Errorf("%w: %s", ErrUnsupportedPredicate, pred)
+		}
+
+		matches, err := evaluatePredicate(inner, key, value)
+		return !matches, err
+
+	case predicateClassEqual, predicateClassNotEqual:
+		matches := matchAttribute(fields[0].(string), key, value, func(candidate any) bool {
+			return equalValues(candidate, fields[1])
+		})
+		return matches == (pred.ClassID() == predicateClassEqual), nil
+
+	case predicateClassGreaterLess:
+		return evaluateGreaterLess(fields, key, value), nil
+
+	case predicateClassBetween:
+		attribute, to, from := fields[0].(string), fields[1], fields[2]
+		return matchAttribute(attribute, key, value, func(candidate any) bool {
+			lower, ok := compareValues(candidate, from)
+			if !ok || lower < 0 {
+				return false
+			}
+
+			upper, ok := compareValues(candidate, to)
+			return ok && upper <= 0
+		}), nil
+
+	case predicateClassIn:
+		attribute, count := fields[0].(string), int(fields[1].(int32))
+		values := fields[2 : 2+count]
+		return matchAttribute(attribute, key, value, func(candidate any) bool {
+			for _, v := range values {
+				if equalValues(candidate, v) {
+					return true
+				}
+			}
+			return false
+		}), nil
+
+	case predicateClassLike, predicateClassILike, predicateClassRegex:
+		return evaluatePattern(pred.ClassID(), fields[0].(string), fields[1].(string), key, value)
+
+	default:
+		return false, fmt.Errorf("%w: %s", ErrUnsupportedPredicate, pred)
+	}
+}
+
+func evaluateJunction(and bool, fields []any, key string, value any) (bool, error) {
+	count := int(fields[0].(int32))
+	for _, field := range fields[1 : 1+count] {
+		inner, ok := field.(predicate.Predicate)
+		if !ok {
+			return false, ErrUnsupportedPredicate
+		}
+
+		matches, err := evaluatePredicate(inner, key, value)
+		if err != nil {
+			return false, err
+		}
+
+		if matches != and {
+			return matches, nil
+		}
+	}
+	return and, nil
+}
+
+func evaluateGreaterLess(fields []any, key string, value any) bool {
+	attribute, reference, equal, less := fields[0].(string), fields[1], fields[2].(bool), fields[3].(bool)
+	return matchAttribute(attribute, key, value, func(candidate any) bool {
+		result, ok := compareValues(candidate, reference)
+		switch {
+		case !ok:
+			return false
+		case result == 0:
+			return equal
+		case less:
+			return result < 0
+		default:
+			return result > 0
+		}
+	})
+}
+
+func evaluatePattern(classID int32, attribute string, expression string, key string, value any) (bool, error) {
+	var pattern string
+	switch classID {
+	case predicateClassRegex:
+		pattern = "^(?:" + expression + ")$"
+	case predicateClassILike:
+		pattern = "(?i)" + likeToRegex(expression)
+	default:
+		pattern = likeToRegex(expression)
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, fmt.Errorf("invalid pattern '%s': %w", expression, err)
+	}
+
+	return matchAttribute(attribute, key, value, func(candidate any) bool {
+		s, ok := candidate.(string)
+		return ok && re.MatchString(s)
+	}), nil
+}
+
+// likeToRegex translates a SQL like expression into an anchored regular expression.
+func likeToRegex(expression string) string {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	escaped := false
+	for _, r := range expression {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	sb.WriteString("$")
+	return sb.String()
+}
+
+// matchAttribute resolves the attribute and reports whether any of the resolved values satisfies match.
+func matchAttribute(attribute string, key string, value any, match func(candidate any) bool) bool {
+	for _, candidate := range resolveAttribute(attribute, key, value) {
+		if match(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAttribute resolves a Hazelcast attribute path such as "foo.bar[0]" or "foo[any].bar".
+// Missing attributes resolve to nil, mirroring how Hazelcast treats them as null.
+func resolveAttribute(attribute string, key string, value any) []any {
+	if attribute == keyAttribute {
+		return []any{key}
+	}
+
+	if attribute == thisAttribute {
+		return []any{value}
+	}
+
+	attribute = strings.TrimPrefix(attribute, thisAttribute+".")
+
+	candidates := []any{value}
+	for segment := range strings.SplitSeq(attribute, ".") {
+		name, indices := splitIndices(segment)
+
+		next := make([]any, 0, len(candidates))
+		for _, candidate := range candidates {
+			object, _ := candidate.(map[string]any)
+			next = append(next, object[name])
+		}
+
+		for _, index := range indices {
+			next = resolveIndex(next, index)
+		}
+		candidates = next
+	}
+
+	return candidates
+}
+
+func splitIndices(segment string) (string, []string) {
+	name, rest, found := strings.Cut(segment, "[")
+	if !found {
+		return name, nil
+	}
+
+	indices := make([]string, 0, 1)
+	for part := range strings.SplitSeq(rest, "[") {
+		indices = append(indices, strings.TrimSuffix(part, "]"))
+	}
+	return name, indices
+}
+
+func resolveIndex(candidates []any, index string) []any {
+	resolved := make([]any, 0, len(candidates))
+	for _, candidate := range candidates {
+		array, _ := candidate.([]any)
+		if index == "any" {
+			resolved = append(resolved, array...)
+			continue
+		}
+
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(array) {
+			resolved = append(resolved, nil)
+			continue
+		}
+		resolved = append(resolved, array[i])
+	}
+	return resolved
+}
+
+func equalValues(a any, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	result, ok := compareValues(a, b)
+	return ok && result == 0
+}
+
+// compareValues compares a JSON value with a predicate value.
+// Like Hazelcast, the predicate value is converted to the type of the attribute where possible.
+func compareValues(a any, b any) (int, bool) {
+	switch av := a.(type) {
+	case float64:
+		bv, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		return cmp.Compare(av, bv), true
+
+	case string:
+		return cmp.Compare(av, fmt.Sprint(b)), true
+
+	case bool:
+		bv, ok := b.(bool)
+		if !ok {
+			parsed, err := strconv.ParseBool(fmt.Sprint(b))
+			if err != nil {
+				return 0, false
+			}
+			bv = parsed
+		}
+
+		if av == bv {
+			return 0, true
+		}
+		if !av {
+			return -1, true
+		}
+		return 1, true
+
+	default:
+		return 0, false
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	if s, ok := value.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// predicateRecorder is a serialization.DataOutput that records the fields written by a predicate.
+// The built-in predicates only write strings, objects, int32 and bool values, so the remaining
+// methods are left to the embedded nil interface.
+type predicateRecorder struct {
+	serialization.DataOutput
+	fields []any
+}
+
+func (r *predicateRecorder) WriteBool(v bool) {
+	r.fields = append(r.fields, v)
+}
+
+func (r *predicateRecorder) WriteInt32(v int32) {
+	r.fields = append(r.fields, v)
+}
+
+func (r *predicateRecorder) WriteString(v string) {
+	r.fields = append(r.fields, v)
+}
+
+func (r *predicateRecorder) WriteObject(v any) {
+	r.fields = append(r.fields, v)
+}
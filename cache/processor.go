@@ -0,0 +1,411 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+)
+
+// The ids the JSON patch processor is serialized with. Hazelcast does not ship a JSON patch processor,
+// so it can only be applied in-process, e.g. by MemoryCache.
+const (
+	DefaultPatchProcessorFactoryID = 2025
+	DefaultPatchProcessorClassID   = 1
+)
+
+var (
+	// ErrProcessorNotSupported is returned if an entry processor cannot be executed by a cache.
+	ErrProcessorNotSupported = errors.New("entry processor is not supported")
+	// ErrPatchTestFailed is returned if a test operation of a JSON patch does not match.
+	ErrPatchTestFailed = errors.New("patch test operation failed")
+)
+
+// EntryProcessor is executed on the cluster members owning the entries it is applied to.
+// Hazelcast only transfers the serialized form of the processor, so a processor with the same
+// factory and class id has to be deployed on the cluster.
+type EntryProcessor interface {
+	serialization.IdentifiedDataSerializable
+}
+
+// LocalEntryProcessor is an EntryProcessor that can also be applied in-process, e.g. by MemoryCache.
+// Apply receives the encoded value, which is nil if there is no entry, and returns the new encoded value
+// together with the result of the processor. Returning a nil value removes the entry.
+type LocalEntryProcessor interface {
+	EntryProcessor
+	Apply(key string, value any) (newValue any, result any, err error)
+}
+
+// ProcessingCache is a Cache that can update entries in place using entry processors.
+// The results are returned as received from the processor, keyed by the key of the processed entry.
+type ProcessingCache[T any] interface {
+	ExecuteOnKey(mapName string, key string, processor EntryProcessor) (any, error)
+	ExecuteOnKeyCtx(ctx context.Context, mapName string, key string, processor EntryProcessor) (any, error)
+	ExecuteOnKeys(mapName string, keys []string, processor EntryProcessor) (map[string]any, error)
+	ExecuteOnKeysCtx(ctx context.Context, mapName string, keys []string, processor EntryProcessor) (map[string]any, error)
+	ExecuteOnEntries(mapName string, query predicate.Predicate, processor EntryProcessor) (map[string]any, error)
+	ExecuteOnEntriesCtx(ctx context.Context, mapName string, query predicate.Predicate, processor EntryProcessor) (map[string]any, error)
+}
+
+// PatchingCache is a ProcessingCache that can apply a JSONPatchProcessor and decode the patched values.
+// It is only implemented by caches that apply processors in-process, since Hazelcast has no JSON patch processor.
+type PatchingCache[T any] interface {
+	ProcessingCache[T]
+	Patch(mapName string, key string, operations ...PatchOperation) (*T, error)
+	PatchCtx(ctx context.Context, mapName string, key string, operations ...PatchOperation) (*T, error)
+	PatchEntries(mapName string, query predicate.Predicate, operations ...PatchOperation) (map[string]T, error)
+	PatchEntriesCtx(ctx context.Context, mapName string, query predicate.Predicate, operations ...PatchOperation) (map[string]T, error)
+}
+
+// PatchOperation is a single operation of a JSON patch as described in RFC 6902.
+// Besides add, remove, replace and test, the non-standard increment operation adds a number to a numeric value.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+func PatchAdd(path string, value any) PatchOperation {
+	return PatchOperation{Op: "add", Path: path, Value: value}
+}
+
+func PatchRemove(path string) PatchOperation {
+	return PatchOperation{Op: "remove", Path: path}
+}
+
+func PatchReplace(path string, value any) PatchOperation {
+	return PatchOperation{Op: "replace", Path: path, Value: value}
+}
+
+func PatchTest(path string, value any) PatchOperation {
+	return PatchOperation{Op: "test", Path: path, Value: value}
+}
+
+func PatchIncrement(path string, delta float64) PatchOperation {
+	return PatchOperation{Op: "increment", Path: path, Value: delta}
+}
+
+// JSONPatchProcessor patches HazelcastJsonValue entries and returns the patched values. Entries that do not exist are skipped.
+// It is serialized as the JSON array of its operations, but there is no server-side implementation,
+// so executing it on a HazelcastCache fails unless a matching processor is deployed on the cluster.
+type JSONPatchProcessor struct {
+	Factory    int32
+	Class      int32
+	Operations []PatchOperation
+}
+
+var _ LocalEntryProcessor = (*JSONPatchProcessor)(nil)
+
+// NewJSONPatchProcessor creates a JSONPatchProcessor using the default ids.
+func NewJSONPatchProcessor(operations ...PatchOperation) *JSONPatchProcessor {
+	return &JSONPatchProcessor{
+		Factory:    DefaultPatchProcessorFactoryID,
+		Class:      DefaultPatchProcessorClassID,
+		Operations: operations,
+	}
+}
+
+func (p *JSONPatchProcessor) FactoryID() int32 {
+	return p.Factory
+}
+
+func (p *JSONPatchProcessor) ClassID() int32 {
+	return p.Class
+}
+
+func (p *JSONPatchProcessor) WriteData(output serialization.DataOutput) {
+	// Marshalling plain operations cannot fail, values are expected to be JSON compatible.
+	bytes, _ := json.Marshal(p.Operations)
+	output.WriteString(string(bytes))
+}
+
+func (p *JSONPatchProcessor) ReadData(input serialization.DataInput) {
+	_ = json.Unmarshal([]byte(input.ReadString()), &p.Operations)
+}
+
+func (p *JSONPatchProcessor) Apply(key string, value any) (any, any, error) {
+	if value == nil {
+		return nil, nil, nil
+	}
+
+	bytes, ok := value.(serialization.JSON)
+	if !ok {
+		return nil, nil, fmt.Errorf("value of cached object with key '%s' is not a HazelcastJsonValue", key)
+	}
+
+	var document any
+	if err := json.Unmarshal(bytes, &document); err != nil {
+		return nil, nil, err
+	}
+
+	document, err := applyPatch(document, p.Operations)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to patch cached object with key '%s': %w", key, err)
+	}
+
+	patched, err := json.Marshal(document)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return serialization.JSON(patched), serialization.JSON(patched), nil
+}
+
+// decodeResults decodes the values returned by a JSONPatchProcessor.
+func decodeResults[T any](codec Codec[T], mapName string, results map[string]any) (map[string]T, error) {
+	values := make(map[string]T, len(results))
+	errs := make(map[string]error)
+	for key, result := range results {
+		if result == nil {
+			continue
+		}
+
+		value, err := decode(codec, mapName, key, result)
+		if err != nil {
+			errs[key] = err
+			continue
+		}
+
+		values[key] = value
+	}
+
+	if len(errs) > 0 {
+		return values, &BatchError{Errors: errs}
+	}
+
+	return values, nil
+}
+
+// applyPatch applies the operations to a document that has been unmarshalled into an any.
+func applyPatch(document any, operations []PatchOperation) (any, error) {
+	for _, operation := range operations {
+		value, err := normalizePatchValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		pointer, err := parsePointer(operation.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch operation.Op {
+		case "add":
+			document, err = setPointer(document, pointer, value, true)
+		case "replace":
+			document, err = setPointer(document, pointer, value, false)
+		case "remove":
+			document, err = removePointer(document, pointer)
+		case "test":
+			err = testPointer(document, pointer, value)
+		case "increment":
+			document, err = incrementPointer(document, pointer, value)
+		default:
+			err = fmt.Errorf("unknown patch operation '%s'", operation.Op)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s '%s': %w", operation.Op, operation.Path, err)
+		}
+	}
+
+	return document, nil
+}
+
+// normalizePatchValue converts a value into its JSON representation, so it can be compared with unmarshalled values.
+func normalizePatchValue(value any) (any, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var normalized any
+	err = json.Unmarshal(bytes, &normalized)
+	return normalized, err
+}
+
+func parsePointer(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer '%s'", path)
+	}
+
+	tokens := strings.Split(path[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// resolveParent returns the container holding the last token of the pointer.
+func resolveParent(document any, pointer []string) (any, error) {
+	current := document
+	for _, token := range pointer[:len(pointer)-1] {
+		child, err := resolveToken(current, token)
+		if err != nil {
+			return nil, err
+		}
+		current = child
+	}
+	return current, nil
+}
+
+func resolveToken(container any, token string) (any, error) {
+	switch c := container.(type) {
+	case map[string]any:
+		child, ok := c[token]
+		if !ok {
+			return nil, fmt.Errorf("member '%s' does not exist", token)
+		}
+		return child, nil
+
+	case []any:
+		index, err := arrayIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		return c[index], nil
+
+	default:
+		return nil, fmt.Errorf("cannot resolve '%s' in a scalar value", token)
+	}
+}
+
+func arrayIndex(token string, maxIndex int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > maxIndex {
+		return 0, fmt.Errorf("invalid array index '%s'", token)
+	}
+	return index, nil
+}
+
+// setPointer adds or replaces a value. Containers are modified in place, except for arrays growing on add.
+func setPointer(document any, pointer []string, value any, add bool) (any, error) {
+	if len(pointer) == 0 {
+		return value, nil
+	}
+
+	parent, err := resolveParent(document, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	token := pointer[len(pointer)-1]
+	switch c := parent.(type) {
+	case map[string]any:
+		if _, ok := c[token]; !ok && !add {
+			return nil, fmt.Errorf("member '%s' does not exist", token)
+		}
+		c[token] = value
+
+	case []any:
+		if !add {
+			index, err := arrayIndex(token, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			c[index] = value
+			break
+		}
+
+		index := len(c)
+		if token != "-" {
+			if index, err = arrayIndex(token, len(c)); err != nil {
+				return nil, err
+			}
+		}
+		return setPointer(document, pointer[:len(pointer)-1], append(c[:index:index], append([]any{value}, c[index:]...)...), false)
+
+	default:
+		return nil, fmt.Errorf("cannot set '%s' in a scalar value", token)
+	}
+
+	return document, nil
+}
+
+func removePointer(document any, pointer []string) (any, error) {
+	if len(pointer) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+
+	parent, err := resolveParent(document, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	token := pointer[len(pointer)-1]
+	switch c := parent.(type) {
+	case map[string]any:
+		if _, ok := c[token]; !ok {
+			return nil, fmt.Errorf("member '%s' does not exist", token)
+		}
+		delete(c, token)
+		return document, nil
+
+	case []any:
+		index, err := arrayIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		return setPointer(document, pointer[:len(pointer)-1], append(c[:index:index], c[index+1:]...), false)
+
+	default:
+		return nil, fmt.Errorf("cannot remove '%s' from a scalar value", token)
+	}
+}
+
+func getPointer(document any, pointer []string) (any, error) {
+	current := document
+	for _, token := range pointer {
+		child, err := resolveToken(current, token)
+		if err != nil {
+			return nil, err
+		}
+		current = child
+	}
+	return current, nil
+}
+
+func testPointer(document any, pointer []string, value any) error {
+	current, err := getPointer(document, pointer)
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(current, value) {
+		return ErrPatchTestFailed
+	}
+	return nil
+}
+
+func incrementPointer(document any, pointer []string, delta any) (any, error) {
+	current, err := getPointer(document, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	number, ok := current.(float64)
+	if !ok {
+		return nil, errors.New("value is not a number")
+	}
+
+	increment, ok := delta.(float64)
+	if !ok {
+		return nil, errors.New("increment is not a number")
+	}
+
+	return setPointer(document, pointer, number+increment, false)
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPatch(t *testing.T) {
+	var tests = []struct {
+		name       string
+		operations []PatchOperation
+		expected   string
+		err        error
+	}{
+		{"add member", []PatchOperation{PatchAdd("/status", "OPEN")}, `{"count":1,"status":"OPEN","tags":["a","b"]}`, nil},
+		{"add array element", []PatchOperation{PatchAdd("/tags/1", "c")}, `{"count":1,"tags":["a","c","b"]}`, nil},
+		{"append array element", []PatchOperation{PatchAdd("/tags/-", "c")}, `{"count":1,"tags":["a","b","c"]}`, nil},
+		{"replace", []PatchOperation{PatchReplace("/count", 5)}, `{"count":5,"tags":["a","b"]}`, nil},
+		{"remove array element", []PatchOperation{PatchRemove("/tags/0")}, `{"count":1,"tags":["b"]}`, nil},
+		{"increment", []PatchOperation{PatchIncrement("/count", 2)}, `{"count":3,"tags":["a","b"]}`, nil},
+		{
+			"test",
+			[]PatchOperation{PatchTest("/tags", []string{"a", "b"}), PatchRemove("/tags")},
+			`{"count":1}`,
+			nil,
+		},
+		{"failed test", []PatchOperation{PatchTest("/count", 2)}, "", ErrPatchTestFailed},
+		{"replace missing member", []PatchOperation{PatchReplace("/missing", 1)}, "", nil},
+		{"invalid pointer", []PatchOperation{PatchRemove("count")}, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertions := assert.New(t)
+
+			var document any
+			assertions.NoError(json.Unmarshal([]byte(`{"count":1,"tags":["a","b"]}`), &document))
+
+			patched, err := applyPatch(document, tt.operations)
+			if tt.expected == "" {
+				assertions.Error(err)
+				if tt.err != nil {
+					assertions.ErrorIs(err, tt.err)
+				}
+				return
+			}
+
+			assertions.NoError(err)
+			bytes, err := json.Marshal(patched)
+			assertions.NoError(err)
+			assertions.JSONEq(tt.expected, string(bytes))
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+)
+
+// Condition is a filter on the fields of the queried type.
+// Field paths are resolved and validated once the query is built.
+type Condition interface {
+	compile(t reflect.Type) (predicate.Predicate, error)
+}
+
+type conditionFunc func(t reflect.Type) (predicate.Predicate, error)
+
+func (f conditionFunc) compile(t reflect.Type) (predicate.Predicate, error) {
+	return f(t)
+}
+
+// fieldCondition creates a condition on a single field whose path is resolved before creating the predicate.
+func fieldCondition(field string, create func(attribute string) predicate.Predicate) Condition {
+	return conditionFunc(func(t reflect.Type) (predicate.Predicate, error) {
+		attribute, err := resolvePath(t, field)
+		if err != nil {
+			return nil, err
+		}
+		return create(attribute), nil
+	})
+}
+
+// Equal matches entries whose field equals the given value.
+func Equal(field string, value any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.Equal(attribute, normalizeValue(value))
+	})
+}
+
+// NotEqual matches entries whose field does not equal the given value.
+func NotEqual(field string, value any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.NotEqual(attribute, normalizeValue(value))
+	})
+}
+
+// In matches entries whose field equals one of the given values.
+func In(field string, values ...any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		normalized := make([]any, len(values))
+		for i, value := range values {
+			normalized[i] = normalizeValue(value)
+		}
+		return predicate.In(attribute, normalized...)
+	})
+}
+
+// Like matches entries whose field matches the given SQL like pattern in a case-sensitive manner.
+func Like(field string, pattern string) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.Like(attribute, pattern)
+	})
+}
+
+// ILike matches entries whose field matches the given SQL like pattern in a case-insensitive manner.
+func ILike(field string, pattern string) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.ILike(attribute, pattern)
+	})
+}
+
+// Regex matches entries whose field fully matches the given regular expression.
+func Regex(field string, pattern string) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.Regex(attribute, pattern)
+	})
+}
+
+// Between matches entries whose field lies within the given inclusive bounds.
+func Between(field string, from any, to any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.Between(attribute, normalizeValue(from), normalizeValue(to))
+	})
+}
+
+// Greater matches entries whose field is greater than the given value.
+func Greater(field string, value any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.Greater(attribute, normalizeValue(value))
+	})
+}
+
+// GreaterOrEqual matches entries whose field is greater than or equal to the given value.
+func GreaterOrEqual(field string, value any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.GreaterOrEqual(attribute, normalizeValue(value))
+	})
+}
+
+// Less matches entries whose field is less than the given value.
+func Less(field string, value any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.Less(attribute, normalizeValue(value))
+	})
+}
+
+// LessOrEqual matches entries whose field is less than or equal to the given value.
+func LessOrEqual(field string, value any) Condition {
+	return fieldCondition(field, func(attribute string) predicate.Predicate {
+		return predicate.LessOrEqual(attribute, normalizeValue(value))
+	})
+}
+
+// And matches entries that satisfy all given conditions.
+func And(conditions ...Condition) Condition {
+	return conditionFunc(func(t reflect.Type) (predicate.Predicate, error) {
+		predicates, err := compileAll(t, conditions)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.And(predicates...), nil
+	})
+}
+
+// Or matches entries that satisfy at least one of the given conditions.
+func Or(conditions ...Condition) Condition {
+	return conditionFunc(func(t reflect.Type) (predicate.Predicate, error) {
+		predicates, err := compileAll(t, conditions)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.Or(predicates...), nil
+	})
+}
+
+// Not matches entries that do not satisfy the given condition.
+func Not(condition Condition) Condition {
+	return conditionFunc(func(t reflect.Type) (predicate.Predicate, error) {
+		pred, err := condition.compile(t)
+		if err != nil {
+			return nil, err
+		}
+		return predicate.Not(pred), nil
+	})
+}
+
+func compileAll(t reflect.Type, conditions []Condition) ([]predicate.Predicate, error) {
+	predicates := make([]predicate.Predicate, 0, len(conditions))
+	for _, condition := range conditions {
+		pred, err := condition.compile(t)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, pred)
+	}
+	return predicates, nil
+}
+
+// normalizeValue converts values into types the Hazelcast client can serialize.
+// Named types such as enums are converted to their underlying kind, other types to their JSON representation.
+func normalizeValue(value any) any {
+	if value == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		bytes, err := json.Marshal(value)
+		if err != nil {
+			return value
+		}
+
+		var normalized any
+		if err := json.Unmarshal(bytes, &normalized); err != nil {
+			return value
+		}
+		return normalized
+	}
+}
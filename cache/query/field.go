@@ -0,0 +1,130 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// KeyField refers to the key of an entry instead of an attribute of its value.
+const KeyField = "__key"
+
+// ErrInvalidField is returned if a field path cannot be resolved against the queried type.
+var ErrInvalidField = errors.New("invalid field")
+
+// resolvePath translates a path of Go field names (or json names) such as "Spec.Subscription.SubscriptionId"
+// into the attribute path Hazelcast uses for the JSON representation of t, e.g. "spec.subscription.subscriptionId".
+// Segments may be suffixed with an index like "[0]" or "[any]" to select elements of slices.
+func resolvePath(t reflect.Type, path string) (string, error) {
+	if path == KeyField {
+		return path, nil
+	}
+
+	if path == "" {
+		return "", fmt.Errorf("%w: empty path", ErrInvalidField)
+	}
+
+	segments := strings.Split(path, ".")
+	resolved := make([]string, 0, len(segments))
+	current := t
+
+	for i, segment := range segments {
+		name, indices, _ := strings.Cut(segment, "[")
+		if indices != "" {
+			indices = "[" + indices
+		}
+
+		current = indirect(current)
+		switch current.Kind() {
+		case reflect.Struct:
+			field, ok := lookupField(current, name)
+			if !ok {
+				return "", fmt.Errorf("%w: '%s' has no field '%s' (path '%s')", ErrInvalidField, current, name, path)
+			}
+			resolved = append(resolved, field.jsonName+indices)
+			current = field.typ
+
+		case reflect.Map:
+			if current.Key().Kind() != reflect.String {
+				return "", fmt.Errorf("%w: map keys of '%s' are not strings (path '%s')", ErrInvalidField, current, path)
+			}
+			resolved = append(resolved, segment)
+			current = current.Elem()
+
+		case reflect.Interface:
+			// Untyped values cannot be validated any further.
+			resolved = append(resolved, segments[i:]...)
+			return strings.Join(resolved, "."), nil
+
+		default:
+			return "", fmt.Errorf("%w: cannot select '%s' of '%s' (path '%s')", ErrInvalidField, name, current, path)
+		}
+
+		for range strings.Count(indices, "[") {
+			current = indirect(current)
+			if current.Kind() != reflect.Slice && current.Kind() != reflect.Array {
+				return "", fmt.Errorf("%w: '%s' is not a slice (path '%s')", ErrInvalidField, name, path)
+			}
+			current = current.Elem()
+		}
+	}
+
+	return strings.Join(resolved, "."), nil
+}
+
+type jsonField struct {
+	name     string
+	jsonName string
+	typ      reflect.Type
+}
+
+// lookupField finds a field by its Go or json name, including fields promoted from embedded structs.
+func lookupField(t reflect.Type, name string) (jsonField, bool) {
+	for _, field := range jsonFields(t) {
+		if field.name == name || field.jsonName == name {
+			return field, true
+		}
+	}
+	return jsonField{}, false
+}
+
+// jsonFields returns the fields of a struct as they appear in its JSON representation.
+func jsonFields(t reflect.Type) []jsonField {
+	fields := make([]jsonField, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		jsonName, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && jsonName == "" && indirect(field.Type).Kind() == reflect.Struct {
+			fields = append(fields, jsonFields(indirect(field.Type))...)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		fields = append(fields, jsonField{name: field.Name, jsonName: jsonName, typ: field.Type})
+	}
+	return fields
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,315 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package query provides a typed builder for cache queries.
+// Field paths refer to the Go fields of the queried type and are translated into the attribute paths
+// of its JSON representation, so queries keep working when json tags change.
+package query
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/telekom/pubsub-horizon-go/cache"
+)
+
+// Direction is the sort direction of an ordering.
+type Direction int
+
+const (
+	Ascending Direction = iota
+	Descending
+)
+
+// ErrInvalidQuery is returned if a query is built with invalid paging or ordering.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// Builder builds a Query for values of type T.
+type Builder[T any] struct {
+	conditions []Condition
+	orders     []order
+	page       int
+	pageSize   int
+}
+
+type order struct {
+	field     string
+	direction Direction
+}
+
+// Query is a validated query for values of type T.
+// It compiles down to a Hazelcast predicate but can also be evaluated in-process.
+type Query[T any] struct {
+	predicate predicate.Predicate
+	orders    []order
+	page      int
+	pageSize  int
+}
+
+// New creates a new Builder for values of type T.
+func New[T any]() *Builder[T] {
+	return new(Builder[T])
+}
+
+// Where adds conditions that all have to be satisfied by matching entries.
+func (b *Builder[T]) Where(conditions ...Condition) *Builder[T] {
+	b.conditions = append(b.conditions, conditions...)
+	return b
+}
+
+// OrderBy adds an ordering by the given field. Multiple orderings are applied in the order they were added.
+func (b *Builder[T]) OrderBy(field string, direction Direction) *Builder[T] {
+	b.orders = append(b.orders, order{field: field, direction: direction})
+	return b
+}
+
+// Page restricts the results to the page with the given zero-based number and size.
+func (b *Builder[T]) Page(page int, size int) *Builder[T] {
+	b.page, b.pageSize = page, size
+	return b
+}
+
+// Build resolves and validates all field paths and creates the Query.
+func (b *Builder[T]) Build() (*Query[T], error) {
+	t := reflect.TypeFor[T]()
+
+	var pred predicate.Predicate
+	switch len(b.conditions) {
+	case 0:
+		pred = predicate.True()
+	case 1:
+		compiled, err := b.conditions[0].compile(t)
+		if err != nil {
+			return nil, err
+		}
+		pred = compiled
+	default:
+		compiled, err := And(b.conditions...).compile(t)
+		if err != nil {
+			return nil, err
+		}
+		pred = compiled
+	}
+
+	orders := make([]order, 0, len(b.orders))
+	for _, o := range b.orders {
+		attribute, err := resolvePath(t, o.field)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.Contains(attribute, "[any]") {
+			return nil, fmt.Errorf("%w: cannot order by multi-valued field '%s'", ErrInvalidQuery, o.field)
+		}
+		orders = append(orders, order{field: attribute, direction: o.direction})
+	}
+
+	if b.page < 0 || b.pageSize < 0 {
+		return nil, fmt.Errorf("%w: page and page size must not be negative", ErrInvalidQuery)
+	}
+
+	return &Query[T]{predicate: pred, orders: orders, page: b.page, pageSize: b.pageSize}, nil
+}
+
+// MustBuild is like Build but panics if the query is invalid.
+// It is intended for queries that are defined once, e.g. as package variables.
+func (b *Builder[T]) MustBuild() *Query[T] {
+	q, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Predicate returns the Hazelcast predicate of the query. Ordering and paging are not part of it.
+func (q *Query[T]) Predicate() predicate.Predicate {
+	return q.predicate
+}
+
+// Matches reports whether the entry with the given key and value satisfies the conditions of the query.
+func (q *Query[T]) Matches(key string, value T) (bool, error) {
+	return cache.EvaluatePredicate(q.predicate, key, value)
+}
+
+// Evaluate filters, orders and pages the given entries in-process.
+// Entries that compare equal are ordered by their key.
+func (q *Query[T]) Evaluate(entries map[string]T) ([]T, error) {
+	matching := make([]T, 0)
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		matches, err := q.Matches(key, entries[key])
+		if err != nil {
+			return nil, err
+		}
+
+		if matches {
+			matching = append(matching, entries[key])
+		}
+	}
+
+	return q.Arrange(matching)
+}
+
+// Arrange orders and pages values that already satisfy the conditions of the query.
+func (q *Query[T]) Arrange(values []T) ([]T, error) {
+	if len(q.orders) > 0 {
+		sortKeys := make([][]any, len(values))
+		for i, value := range values {
+			keys, err := q.sortKeys(value)
+			if err != nil {
+				return nil, err
+			}
+			sortKeys[i] = keys
+		}
+
+		indices := make([]int, len(values))
+		for i := range indices {
+			indices[i] = i
+		}
+
+		slices.SortStableFunc(indices, func(a int, b int) int {
+			return q.compareSortKeys(sortKeys[a], sortKeys[b])
+		})
+
+		sorted := make([]T, len(values))
+		for i, index := range indices {
+			sorted[i] = values[index]
+		}
+		values = sorted
+	}
+
+	return q.paginate(values), nil
+}
+
+// Run executes the query against a cache. Filtering is done by the cache, ordering and paging in-process.
+func (q *Query[T]) Run(ctx context.Context, c cache.QueryableCache[T], mapName string) ([]T, error) {
+	values, err := c.GetQueryCtx(ctx, mapName, q.predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Arrange(values)
+}
+
+func (q *Query[T]) paginate(values []T) []T {
+	if q.pageSize == 0 {
+		return values
+	}
+
+	start := q.page * q.pageSize
+	if start >= len(values) {
+		return []T{}
+	}
+
+	return values[start:min(start+q.pageSize, len(values))]
+}
+
+func (q *Query[T]) sortKeys(value T) ([]any, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document any
+	if err := json.Unmarshal(bytes, &document); err != nil {
+		return nil, err
+	}
+
+	keys := make([]any, len(q.orders))
+	for i, o := range q.orders {
+		keys[i] = lookupAttribute(document, o.field)
+	}
+	return keys, nil
+}
+
+func (q *Query[T]) compareSortKeys(a []any, b []any) int {
+	for i, o := range q.orders {
+		result := compareSortValues(a[i], b[i])
+		if o.direction == Descending {
+			result = -result
+		}
+
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// lookupAttribute resolves a single-valued attribute path within a JSON document.
+func lookupAttribute(document any, attribute string) any {
+	current := document
+	for segment := range strings.SplitSeq(attribute, ".") {
+		name, indices, _ := strings.Cut(segment, "[")
+
+		object, _ := current.(map[string]any)
+		current = object[name]
+
+		for index := range strings.SplitSeq(indices, "[") {
+			if index == "" {
+				continue
+			}
+
+			array, _ := current.([]any)
+			i, err := strconv.Atoi(strings.TrimSuffix(index, "]"))
+			if err != nil || i < 0 || i >= len(array) {
+				return nil
+			}
+			current = array[i]
+		}
+	}
+	return current
+}
+
+// compareSortValues orders JSON values: null before booleans before numbers before strings.
+func compareSortValues(a any, b any) int {
+	rankA, rankB := sortRank(a), sortRank(b)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+
+	switch av := a.(type) {
+	case bool:
+		bv, _ := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case !av:
+			return -1
+		default:
+			return 1
+		}
+	case float64:
+		bv, _ := b.(float64)
+		return cmp.Compare(av, bv)
+	case string:
+		bv, _ := b.(string)
+		return strings.Compare(av, bv)
+	default:
+		return 0
+	}
+}
+
+func sortRank(value any) int {
+	switch value.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	case float64:
+		return 2
+	case string:
+		return 3
+	default:
+		return 4
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/pubsub-horizon-go/cache"
+	"github.com/telekom/pubsub-horizon-go/enum"
+	"github.com/telekom/pubsub-horizon-go/message"
+	"github.com/telekom/pubsub-horizon-go/resource"
+)
+
+func newSubscription(id string, environment string, deliveryType enum.DeliveryType) resource.SubscriptionResource {
+	var subscription resource.SubscriptionResource
+	subscription.Spec.Environment = environment
+	subscription.Spec.Subscription.SubscriptionId = id
+	subscription.Spec.Subscription.DeliveryType = deliveryType
+	subscription.Spec.Subscription.AppliedScopes = []string{"scope-" + id}
+	subscription.Spec.Subscription.Trigger.ResponseFilterMode = enum.ResponseFilterModeInclude
+	subscription.Spec.Subscription.PublisherTrigger.ResponseFilterMode = enum.ResponseFilterModeInclude
+	return subscription
+}
+
+func TestResolvePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"Spec.Environment", "spec.environment"},
+		{"Spec.Subscription.SubscriptionId", "spec.subscription.subscriptionId"},
+		{"spec.subscription.subscriptionId", "spec.subscription.subscriptionId"},
+		{"Spec.Subscription.EnforceGetHealthCheck", "spec.subscription.enforceGetHttpRequestMethodForHealthCheck"},
+		{"Spec.Subscription.AppliedScopes[any]", "spec.subscription.appliedScopes[any]"},
+		{"Spec.Subscription.Trigger.SelectionFilter.foo", "spec.subscription.trigger.selectionFilter.foo"},
+		{"Metadata.Annotations.foo.bar", "metadata.annotations.foo.bar"},
+		{KeyField, KeyField},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			q, err := New[resource.SubscriptionResource]().Where(Equal(tt.path, "foo")).Build()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected+"=foo", q.Predicate().String())
+		})
+	}
+
+	t.Run("embedded", func(t *testing.T) {
+		q, err := New[message.StatusMessage]().Where(Equal("ErrorMessage", "foo")).Build()
+		assert.NoError(t, err)
+		assert.Equal(t, "errorMessage=foo", q.Predicate().String())
+	})
+}
+
+func TestBuild_InvalidField(t *testing.T) {
+	invalid := []Condition{
+		Equal("Spec.Unknown", "foo"),
+		Equal("Spec.Environment.Foo", "foo"),
+		Equal("Spec.Subscription.Callback[any]", "foo"),
+		Not(Or(Equal("Spec.Environment", "foo"), In("Spec.Missing", "bar"))),
+		Equal("", "foo"),
+	}
+
+	for _, condition := range invalid {
+		_, err := New[resource.SubscriptionResource]().Where(condition).Build()
+		assert.ErrorIs(t, err, ErrInvalidField)
+	}
+
+	_, err := New[resource.SubscriptionResource]().OrderBy("Spec.Subscription.AppliedScopes[any]", Ascending).Build()
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = New[resource.SubscriptionResource]().Page(-1, 10).Build()
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	assert.Panics(t, func() {
+		New[resource.SubscriptionResource]().Where(Equal("Spec.Unknown", "foo")).MustBuild()
+	})
+}
+
+func TestQuery_Evaluate(t *testing.T) {
+	entries := map[string]resource.SubscriptionResource{
+		"a": newSubscription("a", "playground", enum.DeliveryTypeCallback),
+		"b": newSubscription("b", "playground", enum.DeliveryTypeSse),
+		"c": newSubscription("c", "integration", enum.DeliveryTypeCallback),
+		"d": newSubscription("d", "playground", enum.DeliveryTypeCallback),
+	}
+
+	ids := func(values []resource.SubscriptionResource) []string {
+		result := make([]string, 0, len(values))
+		for _, value := range values {
+			result = append(result, value.Spec.Subscription.SubscriptionId)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		builder  *Builder[resource.SubscriptionResource]
+		expected []string
+	}{
+		{
+			name:     "all",
+			builder:  New[resource.SubscriptionResource](),
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "equal enum",
+			builder: New[resource.SubscriptionResource]().
+				Where(Equal("Spec.Environment", "playground"), Equal("Spec.Subscription.DeliveryType", enum.DeliveryTypeCallback)),
+			expected: []string{"a", "d"},
+		},
+		{
+			name: "or and not",
+			builder: New[resource.SubscriptionResource]().
+				Where(Or(Equal("Spec.Environment", "integration"), Not(In(KeyField, "a", "c", "d")))),
+			expected: []string{"b", "c"},
+		},
+		{
+			name: "like and between",
+			builder: New[resource.SubscriptionResource]().
+				Where(Like("Spec.Subscription.AppliedScopes[any]", "scope-%"), Between("Spec.Subscription.SubscriptionId", "b", "c")),
+			expected: []string{"b", "c"},
+		},
+		{
+			name: "ordering",
+			builder: New[resource.SubscriptionResource]().
+				OrderBy("Spec.Environment", Descending).
+				OrderBy("Spec.Subscription.SubscriptionId", Descending),
+			expected: []string{"d", "b", "a", "c"},
+		},
+		{
+			name: "paging",
+			builder: New[resource.SubscriptionResource]().
+				OrderBy("Spec.Subscription.SubscriptionId", Ascending).
+				Page(1, 3),
+			expected: []string{"d"},
+		},
+		{
+			name:     "page out of range",
+			builder:  New[resource.SubscriptionResource]().Page(5, 3),
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertions := assert.New(t)
+
+			q, err := tt.builder.Build()
+			assertions.NoError(err)
+
+			results, err := q.Evaluate(entries)
+			assertions.NoError(err)
+			assertions.Equal(tt.expected, ids(results))
+		})
+	}
+}
+
+func TestQuery_Run(t *testing.T) {
+	assertions := assert.New(t)
+
+	memoryCache := cache.NewMemoryCache[resource.SubscriptionResource]()
+	assertions.NoError(memoryCache.Put("subscriptions", "a", newSubscription("a", "playground", enum.DeliveryTypeCallback)))
+	assertions.NoError(memoryCache.Put("subscriptions", "b", newSubscription("b", "playground", enum.DeliveryTypeSse)))
+	assertions.NoError(memoryCache.Put("subscriptions", "c", newSubscription("c", "integration", enum.DeliveryTypeSse)))
+
+	q := New[resource.SubscriptionResource]().
+		Where(Equal("Spec.Subscription.DeliveryType", enum.DeliveryTypeSse)).
+		OrderBy("Spec.Environment", Ascending).
+		MustBuild()
+
+	results, err := q.Run(context.Background(), memoryCache, "subscriptions")
+	assertions.NoError(err)
+	assertions.Len(results, 2)
+	assertions.Equal("c", results[0].Spec.Subscription.SubscriptionId)
+	assertions.Equal("b", results[1].Spec.Subscription.SubscriptionId)
+
+	matches, err := q.Matches("b", newSubscription("b", "playground", enum.DeliveryTypeSse))
+	assertions.NoError(err)
+	assertions.True(matches)
+}
@@ -0,0 +1,424 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+// ReplicatedMap is a map whose entries are replicated to every member, so reads are served locally.
+// It is meant for small, rarely changing data such as configuration flags and supports neither expiry nor queries.
+// Entries only contains the entries whose values could be decoded and reports the others using a *BatchError,
+// unless they are skipped by a decode error handler.
+type ReplicatedMap[T any] interface {
+	Put(mapName string, key string, value T) error
+	PutCtx(ctx context.Context, mapName string, key string, value T) error
+	PutAll(mapName string, values map[string]T) error
+	PutAllCtx(ctx context.Context, mapName string, values map[string]T) error
+	Get(mapName string, key string) (*T, error)
+	GetCtx(ctx context.Context, mapName string, key string) (*T, error)
+	Entries(mapName string) (map[string]T, error)
+	EntriesCtx(ctx context.Context, mapName string) (map[string]T, error)
+	Delete(mapName string, key string) error
+	DeleteCtx(ctx context.Context, mapName string, key string) error
+	Size(mapName string) (int, error)
+	SizeCtx(ctx context.Context, mapName string) (int, error)
+	Clear(mapName string) error
+	ClearCtx(ctx context.Context, mapName string) error
+	AddListener(mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error)
+	AddListenerCtx(ctx context.Context, mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error)
+	AddListenerForKey(mapName string, key string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error)
+	AddListenerForKeyCtx(
+		ctx context.Context,
+		mapName string,
+		key string,
+		listener Listener[T],
+		opts ...ListenerOption,
+	) (ListenerHandle, error)
+	RemoveListener(handle ListenerHandle) error
+	RemoveListenerCtx(ctx context.Context, handle ListenerHandle) error
+}
+
+var (
+	_ ReplicatedMap[any] = (*HazelcastReplicatedMap[any])(nil)
+	_ ReplicatedMap[any] = (*MemoryReplicatedMap[any])(nil)
+)
+
+// HazelcastReplicatedMap is a ReplicatedMap backed by Hazelcast replicated maps.
+// Hazelcast always transfers the values of replicated map events, WithoutValues only skips decoding them.
+type HazelcastReplicatedMap[T any] struct {
+	ctx    context.Context
+	client *hazelcast.Client
+	codec  Codec[T]
+	opts   options[T]
+}
+
+// NewHazelcastReplicatedMap creates a ReplicatedMap using the given client, e.g. the one of a HazelcastCache.
+func NewHazelcastReplicatedMap[T any](client *hazelcast.Client, opts ...Option[T]) *HazelcastReplicatedMap[T] {
+	o := newOptions(opts)
+	return &HazelcastReplicatedMap[T]{ctx: context.Background(), client: client, codec: o.codec, opts: o}
+}
+
+func (m *HazelcastReplicatedMap[T]) Put(mapName string, key string, value T) error {
+	return m.PutCtx(m.ctx, mapName, key, value)
+}
+
+func (m *HazelcastReplicatedMap[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	encoded, err := m.codec.Encode(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value with key '%s': %w", key, err)
+	}
+
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	_, err = mp.Put(ctx, key, encoded)
+	return err
+}
+
+func (m *HazelcastReplicatedMap[T]) PutAll(mapName string, values map[string]T) error {
+	return m.PutAllCtx(m.ctx, mapName, values)
+}
+
+func (m *HazelcastReplicatedMap[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	entries := make([]types.Entry, 0, len(values))
+	for key, value := range values {
+		encoded, err := m.codec.Encode(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode value with key '%s': %w", key, err)
+		}
+
+		entries = append(entries, types.NewEntry(key, encoded))
+	}
+
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	return mp.PutAll(ctx, entries...)
+}
+
+func (m *HazelcastReplicatedMap[T]) Get(mapName string, key string) (*T, error) {
+	return m.GetCtx(m.ctx, mapName, key)
+}
+
+func (m *HazelcastReplicatedMap[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := mp.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return m.opts.miss()
+	}
+
+	decodedValue, err := decode(m.codec, mapName, key, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decodedValue, nil
+}
+
+func (m *HazelcastReplicatedMap[T]) Entries(mapName string) (map[string]T, error) {
+	return m.EntriesCtx(m.ctx, mapName)
+}
+
+func (m *HazelcastReplicatedMap[T]) EntriesCtx(ctx context.Context, mapName string) (map[string]T, error) {
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := mp.GetEntrySet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := make(map[string]any, len(entries))
+	for _, entry := range entries {
+		if key, ok := entry.Key.(string); ok {
+			encoded[key] = entry.Value
+		}
+	}
+
+	return decodeEntries(&m.opts, mapName, encoded)
+}
+
+func (m *HazelcastReplicatedMap[T]) Delete(mapName string, key string) error {
+	return m.DeleteCtx(m.ctx, mapName, key)
+}
+
+func (m *HazelcastReplicatedMap[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	_, err = mp.Remove(ctx, key)
+	return err
+}
+
+func (m *HazelcastReplicatedMap[T]) Size(mapName string) (int, error) {
+	return m.SizeCtx(m.ctx, mapName)
+}
+
+func (m *HazelcastReplicatedMap[T]) SizeCtx(ctx context.Context, mapName string) (int, error) {
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return 0, err
+	}
+
+	return mp.Size(ctx)
+}
+
+func (m *HazelcastReplicatedMap[T]) Clear(mapName string) error {
+	return m.ClearCtx(m.ctx, mapName)
+}
+
+func (m *HazelcastReplicatedMap[T]) ClearCtx(ctx context.Context, mapName string) error {
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return err
+	}
+
+	return mp.Clear(ctx)
+}
+
+func (m *HazelcastReplicatedMap[T]) AddListener(mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error) {
+	return m.AddListenerCtx(m.ctx, mapName, listener, opts...)
+}
+
+func (m *HazelcastReplicatedMap[T]) AddListenerCtx(
+	ctx context.Context,
+	mapName string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	register := func(mp *hazelcast.ReplicatedMap, handler hazelcast.EntryNotifiedHandler) (types.UUID, error) {
+		return mp.AddEntryListener(ctx, handler)
+	}
+	return m.addListener(ctx, mapName, listener, opts, register)
+}
+
+func (m *HazelcastReplicatedMap[T]) AddListenerForKey(
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return m.AddListenerForKeyCtx(m.ctx, mapName, key, listener, opts...)
+}
+
+func (m *HazelcastReplicatedMap[T]) AddListenerForKeyCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	register := func(mp *hazelcast.ReplicatedMap, handler hazelcast.EntryNotifiedHandler) (types.UUID, error) {
+		return mp.AddEntryListenerToKey(ctx, key, handler)
+	}
+	return m.addListener(ctx, mapName, listener, opts, register)
+}
+
+func (m *HazelcastReplicatedMap[T]) RemoveListener(handle ListenerHandle) error {
+	return m.RemoveListenerCtx(m.ctx, handle)
+}
+
+func (m *HazelcastReplicatedMap[T]) RemoveListenerCtx(ctx context.Context, handle ListenerHandle) error {
+	mp, err := m.client.GetReplicatedMap(ctx, handle.MapName)
+	if err != nil {
+		return err
+	}
+
+	if err := mp.RemoveEntryListener(ctx, handle.uuid); err != nil {
+		return fmt.Errorf("failed to remove listener: %w", err)
+	}
+
+	return nil
+}
+
+func (m *HazelcastReplicatedMap[T]) addListener(
+	ctx context.Context,
+	mapName string,
+	listener Listener[T],
+	opts []ListenerOption,
+	register func(mp *hazelcast.ReplicatedMap, handler hazelcast.EntryNotifiedHandler) (types.UUID, error),
+) (ListenerHandle, error) {
+	mp, err := m.client.GetReplicatedMap(ctx, mapName)
+	if err != nil {
+		return ListenerHandle{}, err
+	}
+
+	o := newListenerOptions(opts)
+	uuid, err := register(mp, func(event *hazelcast.EntryNotified) {
+		dispatchEvent(event, listener, m.codec, o.includeValue)
+	})
+	if err != nil {
+		return ListenerHandle{}, fmt.Errorf("failed to add listener: %w", err)
+	}
+
+	return newListenerHandle(mapName, uuid), nil
+}
+
+// MemoryReplicatedMap is an in-process implementation of ReplicatedMap. Listeners behave like the ones of MemoryCache.
+type MemoryReplicatedMap[T any] struct {
+	cache *MemoryCache[T]
+}
+
+// NewMemoryReplicatedMap creates a new empty MemoryReplicatedMap.
+func NewMemoryReplicatedMap[T any](opts ...Option[T]) *MemoryReplicatedMap[T] {
+	return &MemoryReplicatedMap[T]{cache: NewMemoryCache(opts...)}
+}
+
+func (m *MemoryReplicatedMap[T]) Put(mapName string, key string, value T) error {
+	return m.cache.Put(mapName, key, value)
+}
+
+func (m *MemoryReplicatedMap[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	return m.cache.PutCtx(ctx, mapName, key, value)
+}
+
+func (m *MemoryReplicatedMap[T]) PutAll(mapName string, values map[string]T) error {
+	return m.cache.PutAll(mapName, values)
+}
+
+func (m *MemoryReplicatedMap[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	return m.cache.PutAllCtx(ctx, mapName, values)
+}
+
+func (m *MemoryReplicatedMap[T]) Get(mapName string, key string) (*T, error) {
+	return m.cache.Get(mapName, key)
+}
+
+func (m *MemoryReplicatedMap[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	return m.cache.GetCtx(ctx, mapName, key)
+}
+
+func (m *MemoryReplicatedMap[T]) Entries(mapName string) (map[string]T, error) {
+	return m.EntriesCtx(context.Background(), mapName)
+}
+
+func (m *MemoryReplicatedMap[T]) EntriesCtx(ctx context.Context, mapName string) (map[string]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return decodeEntries(&m.cache.opts, mapName, m.cache.snapshot(mapName))
+}
+
+func (m *MemoryReplicatedMap[T]) Delete(mapName string, key string) error {
+	return m.cache.Delete(mapName, key)
+}
+
+func (m *MemoryReplicatedMap[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	return m.cache.DeleteCtx(ctx, mapName, key)
+}
+
+func (m *MemoryReplicatedMap[T]) Size(mapName string) (int, error) {
+	return m.SizeCtx(context.Background(), mapName)
+}
+
+func (m *MemoryReplicatedMap[T]) SizeCtx(ctx context.Context, mapName string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return len(m.cache.snapshot(mapName)), nil
+}
+
+func (m *MemoryReplicatedMap[T]) Clear(mapName string) error {
+	return m.ClearCtx(context.Background(), mapName)
+}
+
+func (m *MemoryReplicatedMap[T]) ClearCtx(ctx context.Context, mapName string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m.cache.Clear(mapName)
+	return nil
+}
+
+func (m *MemoryReplicatedMap[T]) AddListener(mapName string, listener Listener[T], opts ...ListenerOption) (ListenerHandle, error) {
+	return m.cache.AddListener(mapName, listener, opts...)
+}
+
+func (m *MemoryReplicatedMap[T]) AddListenerCtx(
+	ctx context.Context,
+	mapName string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return m.cache.AddListenerCtx(ctx, mapName, listener, opts...)
+}
+
+func (m *MemoryReplicatedMap[T]) AddListenerForKey(
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return m.cache.AddListenerForKey(mapName, key, listener, opts...)
+}
+
+func (m *MemoryReplicatedMap[T]) AddListenerForKeyCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	listener Listener[T],
+	opts ...ListenerOption,
+) (ListenerHandle, error) {
+	return m.cache.AddListenerForKeyCtx(ctx, mapName, key, listener, opts...)
+}
+
+func (m *MemoryReplicatedMap[T]) RemoveListener(handle ListenerHandle) error {
+	return m.cache.RemoveListener(handle)
+}
+
+func (m *MemoryReplicatedMap[T]) RemoveListenerCtx(ctx context.Context, handle ListenerHandle) error {
+	return m.cache.RemoveListenerCtx(ctx, handle)
+}
+
+// Close stops the delivery of events to listeners.
+func (m *MemoryReplicatedMap[T]) Close() {
+	m.cache.Close()
+}
+
+func decodeEntries[T any](opts *options[T], mapName string, encoded map[string]any) (map[string]T, error) {
+	values := make(map[string]T, len(encoded))
+	errs := make(map[string]error)
+	for key, value := range encoded {
+		decoded, err := decode(opts.codec, mapName, key, value)
+		if opts.skipDecodeError(err) {
+			continue
+		} else if err != nil {
+			errs[key] = err
+			continue
+		}
+
+		values[key] = decoded
+	}
+
+	if len(errs) > 0 {
+		return values, &BatchError{Errors: errs}
+	}
+
+	return values, nil
+}
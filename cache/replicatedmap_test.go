@@ -0,0 +1,123 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryReplicatedMap(t *testing.T) {
+	replicatedMap := NewMemoryReplicatedMap[TestDummy]()
+	defer replicatedMap.Close()
+
+	testReplicatedMap(t, replicatedMap)
+}
+
+func TestMemoryReplicatedMap_Errors(t *testing.T) {
+	testReplicatedMapErrors(t,
+		func(opts ...Option[TestDummy]) ReplicatedMap[TestDummy] {
+			return NewMemoryReplicatedMap(opts...)
+		},
+		func(replicatedMap ReplicatedMap[TestDummy], key string) {
+			memoryMap := replicatedMap.(*MemoryReplicatedMap[TestDummy])
+			memoryMap.cache.maps["errorReplicatedMap"][key] = &memoryEntry{value: serialization.JSON(`{"foo": 1}`)}
+		},
+	)
+}
+
+func testReplicatedMap(t *testing.T, replicatedMap ReplicatedMap[TestDummy]) {
+	t.Helper()
+	assertions := assert.New(t)
+
+	listener := &ExtendedMockListener{}
+	handle, err := replicatedMap.AddListener("testReplicatedMap", listener)
+	assertions.NoError(err)
+
+	keyListener := &MockListener[TestDummy]{}
+	keyHandle, err := replicatedMap.AddListenerForKey("testReplicatedMap", "b", keyListener)
+	assertions.NoError(err)
+
+	assertions.NoError(replicatedMap.Put("testReplicatedMap", "a", TestDummy{Foo: "bar"}))
+	assertions.NoError(replicatedMap.PutAll("testReplicatedMap", map[string]TestDummy{"b": {Foo: "baz"}, "c": {Foo: "fizz"}}))
+
+	value, err := replicatedMap.Get("testReplicatedMap", "a")
+	assertions.NoError(err)
+	assertions.Equal(&TestDummy{Foo: "bar"}, value)
+
+	entries, err := replicatedMap.Entries("testReplicatedMap")
+	assertions.NoError(err)
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}, "b": {Foo: "baz"}, "c": {Foo: "fizz"}}, entries)
+
+	assertions.NoError(replicatedMap.Delete("testReplicatedMap", "a"))
+	size, err := replicatedMap.Size("testReplicatedMap")
+	assertions.NoError(err)
+	assertions.Equal(2, size)
+
+	assertions.NoError(replicatedMap.Clear("testReplicatedMap"))
+	missing, err := replicatedMap.Get("testReplicatedMap", "b")
+	assertions.NoError(err)
+	assertions.Nil(missing)
+
+	assertions.Eventually(func() bool {
+		deleted, _, _, cleared := listener.removals()
+		return len(listener.receivedKeys()) == 3 && deleted == 1 && cleared == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assertions.Equal([]string{"b"}, keyListener.receivedKeys())
+
+	assertions.NoError(replicatedMap.RemoveListener(handle))
+	assertions.NoError(replicatedMap.RemoveListener(keyHandle))
+}
+
+// testReplicatedMapErrors checks the handling of misses and corrupt values. corrupt stores a value that cannot be decoded
+// in the map "errorReplicatedMap" after it has been populated.
+func testReplicatedMapErrors(
+	t *testing.T,
+	newReplicatedMap func(opts ...Option[TestDummy]) ReplicatedMap[TestDummy],
+	corrupt func(replicatedMap ReplicatedMap[TestDummy], key string),
+) {
+	t.Helper()
+	assertions := assert.New(t)
+
+	var skipped []string
+	replicatedMaps := []ReplicatedMap[TestDummy]{
+		newReplicatedMap(),
+		newReplicatedMap(WithNotFoundError[TestDummy]()),
+		newReplicatedMap(WithDecodeErrorHandler[TestDummy](func(err *DecodeError) {
+			skipped = append(skipped, err.Key)
+		})),
+	}
+	for _, replicatedMap := range replicatedMaps {
+		assertions.NoError(replicatedMap.Put("errorReplicatedMap", "a", TestDummy{Foo: "bar"}))
+		corrupt(replicatedMap, "corrupt")
+	}
+
+	plain, notFound, skipping := replicatedMaps[0], replicatedMaps[1], replicatedMaps[2]
+
+	value, err := plain.Get("errorReplicatedMap", "missing")
+	assertions.NoError(err)
+	assertions.Nil(value)
+
+	_, err = notFound.Get("errorReplicatedMap", "missing")
+	assertions.ErrorIs(err, ErrNotFound)
+
+	entries, err := plain.Entries("errorReplicatedMap")
+	var batchErr *BatchError
+	assertions.ErrorAs(err, &batchErr)
+	assertions.Contains(batchErr.Errors, "corrupt")
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}}, entries)
+
+	entries, err = skipping.Entries("errorReplicatedMap")
+	assertions.NoError(err)
+	assertions.Equal(map[string]TestDummy{"a": {Foo: "bar"}}, entries)
+	assertions.Equal([]string{"corrupt"}, skipped)
+
+	for _, replicatedMap := range replicatedMaps {
+		assertions.NoError(replicatedMap.Clear("errorReplicatedMap"))
+	}
+}
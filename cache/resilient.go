@@ -0,0 +1,454 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/hzerrors"
+)
+
+// Operation identifies a cache operation for which a RetryPolicy can be configured.
+type Operation string
+
+const (
+	OperationPut       Operation = "put"
+	OperationGet       Operation = "get"
+	OperationDelete    Operation = "delete"
+	OperationPutAll    Operation = "put_all"
+	OperationGetAll    Operation = "get_all"
+	OperationDeleteAll Operation = "delete_all"
+)
+
+// RetryPolicy defines how often an operation is attempted and how long a single attempt may take.
+// The backoff between attempts doubles after every attempt up to MaxBackoff. A Timeout of zero disables the timeout.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	Timeout     time.Duration
+}
+
+// DefaultRetryPolicy is used for all operations without a specific policy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     50 * time.Millisecond,
+	MaxBackoff:  time.Second,
+	Timeout:     5 * time.Second,
+}
+
+// ResilienceOption configures a ResilientCache.
+type ResilienceOption func(*resilienceOptions)
+
+type resilienceOptions struct {
+	defaultPolicy   RetryPolicy
+	policies        map[Operation]RetryPolicy
+	retryable       func(err error) bool
+	staleFallback   bool
+	staleMaxAge     time.Duration
+	staleMaxEntries int
+}
+
+// DefaultStaleMaxEntries is the number of local copies kept by the stale fallback unless configured otherwise.
+const DefaultStaleMaxEntries = 10000
+
+// WithRetryPolicy sets the policy of all operations without a specific policy.
+func WithRetryPolicy(policy RetryPolicy) ResilienceOption {
+	return func(o *resilienceOptions) {
+		o.defaultPolicy = policy
+	}
+}
+
+// WithOperationRetryPolicy sets the policy of a single operation.
+func WithOperationRetryPolicy(operation Operation, policy RetryPolicy) ResilienceOption {
+	return func(o *resilienceOptions) {
+		o.policies[operation] = policy
+	}
+}
+
+// WithRetryClassifier sets the function deciding whether an error is retryable. IsRetryable is used by default.
+func WithRetryClassifier(retryable func(err error) bool) ResilienceOption {
+	return func(o *resilienceOptions) {
+		o.retryable = retryable
+	}
+}
+
+// WithStaleFallback keeps a local copy of the values read and written through the cache. If the cache is unavailable,
+// Get and GetAll return the local copy if it is not older than maxAge. A maxAge of zero accepts copies of any age.
+// Expired copies and the least recently stored copies beyond DefaultStaleMaxEntries are dropped.
+func WithStaleFallback(maxAge time.Duration) ResilienceOption {
+	return func(o *resilienceOptions) {
+		o.staleFallback = true
+		o.staleMaxAge = maxAge
+	}
+}
+
+// WithStaleMaxEntries limits the number of local copies kept by the stale fallback.
+func WithStaleMaxEntries(maxEntries int) ResilienceOption {
+	return func(o *resilienceOptions) {
+		o.staleMaxEntries = maxEntries
+	}
+}
+
+// IsRetryable reports whether an error is caused by a temporary unavailability of the cache,
+// e.g. a timed out attempt or a disconnected Hazelcast client. Decode errors and canceled contexts are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, ErrDecode) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var retryableError *hzerrors.RetryableError
+	if errors.As(err, &retryableError) {
+		return true
+	}
+
+	for _, target := range []error{
+		context.DeadlineExceeded,
+		hzerrors.ErrClientOffline,
+		hzerrors.ErrHazelcastInstanceNotActive,
+		hzerrors.ErrHazelcastOverLoad,
+		hzerrors.ErrOperationTimeout,
+		hzerrors.ErrTimeout,
+		hzerrors.ErrTargetDisconnected,
+		hzerrors.ErrIO,
+		hzerrors.ErrSocket,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ResilientCache retries the operations of the wrapped Cache that failed with retryable errors.
+// If an operation still fails after all attempts, the returned error wraps ErrCacheUnavailable and the last error.
+// Errors that are not retryable, e.g. ErrDecode, are returned immediately and unchanged.
+// Listener operations are passed through without retries.
+type ResilientCache[T any] struct {
+	Cache[T]
+	opts  resilienceOptions
+	stats resilienceCounters
+
+	mu    sync.RWMutex
+	local map[nearCacheKey]*list.Element
+	lru   *list.List
+}
+
+var _ Cache[any] = (*ResilientCache[any])(nil)
+
+// ResilientCacheStats contains the counters of a ResilientCache.
+type ResilientCacheStats struct {
+	Retries     uint64
+	Unavailable uint64
+	StaleReads  uint64
+}
+
+type resilienceCounters struct {
+	retries     atomic.Uint64
+	unavailable atomic.Uint64
+	staleReads  atomic.Uint64
+}
+
+type staleEntry[T any] struct {
+	key    nearCacheKey
+	value  T
+	stored time.Time
+}
+
+// NewResilientCache wraps the given cache.
+func NewResilientCache[T any](cache Cache[T], opts ...ResilienceOption) *ResilientCache[T] {
+	o := resilienceOptions{
+		defaultPolicy:   DefaultRetryPolicy,
+		policies:        make(map[Operation]RetryPolicy),
+		retryable:       IsRetryable,
+		staleMaxEntries: DefaultStaleMaxEntries,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &ResilientCache[T]{Cache: cache, opts: o, local: make(map[nearCacheKey]*list.Element), lru: list.New()}
+}
+
+// Stats returns a snapshot of the counters.
+func (c *ResilientCache[T]) Stats() ResilientCacheStats {
+	return ResilientCacheStats{
+		Retries:     c.stats.retries.Load(),
+		Unavailable: c.stats.unavailable.Load(),
+		StaleReads:  c.stats.staleReads.Load(),
+	}
+}
+
+func (c *ResilientCache[T]) Put(mapName string, key string, value T) error {
+	return c.PutCtx(context.Background(), mapName, key, value)
+}
+
+func (c *ResilientCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	err := c.do(ctx, OperationPut, mapName, func(ctx context.Context) error {
+		return c.Cache.PutCtx(ctx, mapName, key, value)
+	})
+	c.remember(err, mapName, map[string]T{key: value})
+	return err
+}
+
+func (c *ResilientCache[T]) PutWithTTL(mapName string, key string, value T, ttl time.Duration) error {
+	return c.PutWithTTLCtx(context.Background(), mapName, key, value, ttl)
+}
+
+// PutWithTTLCtx stores the value with a TTL. The value is not kept as a stale copy, since it is meant to expire,
+// but the stale copy of the previous value is dropped.
+func (c *ResilientCache[T]) PutWithTTLCtx(ctx context.Context, mapName string, key string, value T, ttl time.Duration) error {
+	c.forget(mapName, []string{key})
+	return c.do(ctx, OperationPut, mapName, func(ctx context.Context) error {
+		return c.Cache.PutWithTTLCtx(ctx, mapName, key, value, ttl)
+	})
+}
+
+func (c *ResilientCache[T]) PutWithTTLAndMaxIdle(mapName string, key string, value T, ttl time.Duration, maxIdle time.Duration) error {
+	return c.PutWithTTLAndMaxIdleCtx(context.Background(), mapName, key, value, ttl, maxIdle)
+}
+
+// PutWithTTLAndMaxIdleCtx stores the value with a TTL and max idle time. Like PutWithTTLCtx, it drops the stale copy.
+func (c *ResilientCache[T]) PutWithTTLAndMaxIdleCtx(
+	ctx context.Context,
+	mapName string,
+	key string,
+	value T,
+	ttl time.Duration,
+	maxIdle time.Duration,
+) error {
+	c.forget(mapName, []string{key})
+	return c.do(ctx, OperationPut, mapName, func(ctx context.Context) error {
+		return c.Cache.PutWithTTLAndMaxIdleCtx(ctx, mapName, key, value, ttl, maxIdle)
+	})
+}
+
+func (c *ResilientCache[T]) Get(mapName string, key string) (*T, error) {
+	return c.GetCtx(context.Background(), mapName, key)
+}
+
+func (c *ResilientCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	var value *T
+	err := c.do(ctx, OperationGet, mapName, func(ctx context.Context) error {
+		var err error
+		value, err = c.Cache.GetCtx(ctx, mapName, key)
+		return err
+	})
+
+	switch {
+	case err == nil && value != nil:
+		c.remember(nil, mapName, map[string]T{key: *value})
+	case err == nil, errors.Is(err, ErrNotFound):
+		c.forget(mapName, []string{key})
+	case errors.Is(err, ErrCacheUnavailable):
+		if stale, ok := c.stale(mapName, []string{key}); ok {
+			staleValue := stale[key]
+			return &staleValue, nil
+		}
+	}
+
+	return value, err
+}
+
+func (c *ResilientCache[T]) Delete(mapName string, key string) error {
+	return c.DeleteCtx(context.Background(), mapName, key)
+}
+
+func (c *ResilientCache[T]) DeleteCtx(ctx context.Context, mapName string, key string) error {
+	c.forget(mapName, []string{key})
+	return c.do(ctx, OperationDelete, mapName, func(ctx context.Context) error {
+		return c.Cache.DeleteCtx(ctx, mapName, key)
+	})
+}
+
+func (c *ResilientCache[T]) PutAll(mapName string, values map[string]T) error {
+	return c.PutAllCtx(context.Background(), mapName, values)
+}
+
+func (c *ResilientCache[T]) PutAllCtx(ctx context.Context, mapName string, values map[string]T) error {
+	err := c.do(ctx, OperationPutAll, mapName, func(ctx context.Context) error {
+		return c.Cache.PutAllCtx(ctx, mapName, values)
+	})
+	c.remember(err, mapName, values)
+	return err
+}
+
+func (c *ResilientCache[T]) GetAll(mapName string, keys []string) (map[string]T, error) {
+	return c.GetAllCtx(context.Background(), mapName, keys)
+}
+
+// GetAllCtx returns the values of the given keys. If the cache is unavailable, the stale copies are only returned
+// if there is one for every key, since missing keys cannot be told apart from keys that do not exist.
+func (c *ResilientCache[T]) GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	var values map[string]T
+	err := c.do(ctx, OperationGetAll, mapName, func(ctx context.Context) error {
+		var err error
+		values, err = c.Cache.GetAllCtx(ctx, mapName, keys)
+		return err
+	})
+
+	if err == nil {
+		c.remember(nil, mapName, values)
+		c.forget(mapName, slices.DeleteFunc(slices.Clone(keys), func(key string) bool {
+			_, found := values[key]
+			return found
+		}))
+	} else if errors.Is(err, ErrCacheUnavailable) {
+		if stale, ok := c.stale(mapName, keys); ok {
+			return stale, nil
+		}
+	}
+
+	return values, err
+}
+
+func (c *ResilientCache[T]) DeleteAll(mapName string, keys []string) error {
+	return c.DeleteAllCtx(context.Background(), mapName, keys)
+}
+
+func (c *ResilientCache[T]) DeleteAllCtx(ctx context.Context, mapName string, keys []string) error {
+	c.forget(mapName, keys)
+	return c.do(ctx, OperationDeleteAll, mapName, func(ctx context.Context) error {
+		return c.Cache.DeleteAllCtx(ctx, mapName, keys)
+	})
+}
+
+// do runs an operation until it succeeds, fails with an error that is not retryable or runs out of attempts.
+func (c *ResilientCache[T]) do(ctx context.Context, operation Operation, mapName string, fn func(ctx context.Context) error) error {
+	policy, ok := c.opts.policies[operation]
+	if !ok {
+		policy = c.opts.defaultPolicy
+	}
+
+	backoff := policy.Backoff
+	attempts := max(policy.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := c.attempt(ctx, policy.Timeout, fn)
+		if err == nil || !c.opts.retryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		if attempt >= attempts {
+			c.stats.unavailable.Add(1)
+			return fmt.Errorf("%w: %s on map '%s' failed after %d attempts: %w", ErrCacheUnavailable, operation, mapName, attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		c.stats.retries.Add(1)
+		backoff *= 2
+		if policy.MaxBackoff > 0 {
+			backoff = min(backoff, policy.MaxBackoff)
+		}
+	}
+}
+
+func (*ResilientCache[T]) attempt(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(attemptCtx)
+}
+
+// remember stores local copies of successfully written or read values if the stale fallback is enabled.
+// The copies of values that failed to be written are dropped, since the cache might contain either value.
+func (c *ResilientCache[T]) remember(err error, mapName string, values map[string]T) {
+	if !c.opts.staleFallback {
+		return
+	}
+
+	if err != nil {
+		c.forget(mapName, slices.Collect(maps.Keys(values)))
+		return
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, value := range values {
+		entry := &staleEntry[T]{key: nearCacheKey{mapName: mapName, key: key}, value: value, stored: now}
+		if element, ok := c.local[entry.key]; ok {
+			element.Value = entry
+			c.lru.MoveToFront(element)
+			continue
+		}
+		c.local[entry.key] = c.lru.PushFront(entry)
+	}
+
+	c.evict(now)
+}
+
+// evict drops the oldest copies while there are too many or they are expired. Copies are ordered by their age.
+func (c *ResilientCache[T]) evict(now time.Time) {
+	for oldest := c.lru.Back(); oldest != nil; oldest = c.lru.Back() {
+		entry := oldest.Value.(*staleEntry[T])
+		expired := c.opts.staleMaxAge > 0 && now.Sub(entry.stored) > c.opts.staleMaxAge
+		if !expired && c.lru.Len() <= c.opts.staleMaxEntries {
+			return
+		}
+
+		c.lru.Remove(oldest)
+		delete(c.local, entry.key)
+	}
+}
+
+func (c *ResilientCache[T]) forget(mapName string, keys []string) {
+	if !c.opts.staleFallback {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if element, ok := c.local[nearCacheKey{mapName: mapName, key: key}]; ok {
+			c.lru.Remove(element)
+			delete(c.local, nearCacheKey{mapName: mapName, key: key})
+		}
+	}
+}
+
+// stale returns the local copies of all keys, or false if any of them is missing or too old.
+func (c *ResilientCache[T]) stale(mapName string, keys []string) (map[string]T, bool) {
+	if !c.opts.staleFallback {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	values := make(map[string]T, len(keys))
+	for _, key := range keys {
+		element, ok := c.local[nearCacheKey{mapName: mapName, key: key}]
+		if !ok {
+			return nil, false
+		}
+
+		entry := element.Value.(*staleEntry[T])
+		if c.opts.staleMaxAge > 0 && time.Since(entry.stored) > c.opts.staleMaxAge {
+			return nil, false
+		}
+		values[key] = entry.value
+	}
+
+	c.stats.staleReads.Add(1)
+	return values, true
+}
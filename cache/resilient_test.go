@@ -0,0 +1,233 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/hzerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+// unavailableCache fails the configured number of calls with the given error before delegating to a MemoryCache.
+type unavailableCache[T any] struct {
+	*MemoryCache[T]
+	failures atomic.Int64
+	calls    atomic.Int64
+	err      error
+}
+
+func (c *unavailableCache[T]) fail() error {
+	c.calls.Add(1)
+	if c.failures.Add(-1) >= 0 {
+		return c.err
+	}
+	return nil
+}
+
+func (c *unavailableCache[T]) PutCtx(ctx context.Context, mapName string, key string, value T) error {
+	if err := c.fail(); err != nil {
+		return err
+	}
+	return c.MemoryCache.PutCtx(ctx, mapName, key, value)
+}
+
+func (c *unavailableCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	if err := c.fail(); err != nil {
+		return nil, err
+	}
+	return c.MemoryCache.GetCtx(ctx, mapName, key)
+}
+
+func (c *unavailableCache[T]) GetAllCtx(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	if err := c.fail(); err != nil {
+		return nil, err
+	}
+	return c.MemoryCache.GetAllCtx(ctx, mapName, keys)
+}
+
+func newUnavailableCache(failures int64, err error) *unavailableCache[TestDummy] {
+	c := &unavailableCache[TestDummy]{MemoryCache: NewMemoryCache[TestDummy](), err: err}
+	c.failures.Store(failures)
+	return c
+}
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, Timeout: time.Second}
+
+func TestIsRetryable(t *testing.T) {
+	assertions := assert.New(t)
+
+	assertions.True(IsRetryable(hzerrors.ErrClientOffline))
+	assertions.True(IsRetryable(hzerrors.ErrPartitionMigrating))
+	assertions.True(IsRetryable(context.DeadlineExceeded))
+	assertions.False(IsRetryable(nil))
+	assertions.False(IsRetryable(context.Canceled))
+	assertions.False(IsRetryable(errors.Join(ErrDecode, hzerrors.ErrClientOffline)))
+	assertions.False(IsRetryable(hzerrors.ErrIllegalArgument))
+}
+
+func TestResilientCache_Retry(t *testing.T) {
+	assertions := assert.New(t)
+	backend := newUnavailableCache(2, hzerrors.ErrClientOffline)
+	defer backend.Close()
+
+	resilientCache := NewResilientCache[TestDummy](backend, WithRetryPolicy(testRetryPolicy))
+
+	assertions.NoError(resilientCache.Put("testMap", "dummy", TestDummy{Foo: "bar"}))
+	assertions.Equal(int64(3), backend.calls.Load())
+
+	dummy, err := resilientCache.Get("testMap", "dummy")
+	assertions.NoError(err)
+	assertions.Equal("bar", dummy.Foo)
+	assertions.Equal(uint64(2), resilientCache.Stats().Retries)
+}
+
+func TestResilientCache_Unavailable(t *testing.T) {
+	assertions := assert.New(t)
+	backend := newUnavailableCache(10, hzerrors.ErrClientOffline)
+	defer backend.Close()
+
+	resilientCache := NewResilientCache[TestDummy](backend,
+		WithRetryPolicy(testRetryPolicy),
+		WithOperationRetryPolicy(OperationGet, RetryPolicy{MaxAttempts: 1}),
+	)
+
+	err := resilientCache.Put("testMap", "dummy", TestDummy{Foo: "bar"})
+	assertions.ErrorIs(err, ErrCacheUnavailable)
+	assertions.ErrorIs(err, hzerrors.ErrClientOffline)
+	assertions.Equal(int64(3), backend.calls.Load())
+
+	_, err = resilientCache.Get("testMap", "dummy")
+	assertions.ErrorIs(err, ErrCacheUnavailable)
+	assertions.Equal(int64(4), backend.calls.Load())
+	assertions.Equal(uint64(2), resilientCache.Stats().Unavailable)
+}
+
+func TestResilientCache_Fatal(t *testing.T) {
+	assertions := assert.New(t)
+	backend := newUnavailableCache(10, hzerrors.ErrIllegalArgument)
+	defer backend.Close()
+
+	resilientCache := NewResilientCache[TestDummy](backend, WithRetryPolicy(testRetryPolicy))
+
+	err := resilientCache.Put("testMap", "dummy", TestDummy{Foo: "bar"})
+	assertions.ErrorIs(err, hzerrors.ErrIllegalArgument)
+	assertions.NotErrorIs(err, ErrCacheUnavailable)
+	assertions.Equal(int64(1), backend.calls.Load())
+
+	t.Run("decode errors", func(t *testing.T) {
+		backend.failures.Store(0)
+		backend.calls.Store(0)
+		assertions.NoError(backend.MemoryCache.Put("testMap", "corrupt", TestDummy{Foo: "bar"}))
+		backend.maps["testMap"]["corrupt"] = &memoryEntry{value: []byte("not json")}
+
+		_, err := resilientCache.Get("testMap", "corrupt")
+		assertions.ErrorIs(err, ErrDecode)
+		assertions.NotErrorIs(err, ErrCacheUnavailable)
+		assertions.Equal(int64(1), backend.calls.Load())
+	})
+}
+
+func TestResilientCache_Timeout(t *testing.T) {
+	assertions := assert.New(t)
+	backend := newUnavailableCache(0, nil)
+	defer backend.Close()
+
+	slow := &slowCache[TestDummy]{Cache: backend, delay: time.Second}
+	resilientCache := NewResilientCache[TestDummy](slow, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 2,
+		Backoff:     time.Millisecond,
+		Timeout:     10 * time.Millisecond,
+	}))
+
+	start := time.Now()
+	_, err := resilientCache.Get("testMap", "dummy")
+	assertions.ErrorIs(err, ErrCacheUnavailable)
+	assertions.ErrorIs(err, context.DeadlineExceeded)
+	assertions.Less(time.Since(start), 500*time.Millisecond)
+}
+
+func TestResilientCache_StaleFallback(t *testing.T) {
+	assertions := assert.New(t)
+	backend := newUnavailableCache(0, hzerrors.ErrClientOffline)
+	defer backend.Close()
+
+	resilientCache := NewResilientCache[TestDummy](backend,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithStaleFallback(time.Minute),
+	)
+
+	assertions.NoError(resilientCache.Put("testMap", "written", TestDummy{Foo: "written"}))
+	assertions.NoError(backend.MemoryCache.Put("testMap", "read", TestDummy{Foo: "read"}))
+	_, err := resilientCache.Get("testMap", "read")
+	assertions.NoError(err)
+
+	backend.failures.Store(10)
+
+	dummy, err := resilientCache.Get("testMap", "written")
+	assertions.NoError(err)
+	assertions.Equal("written", dummy.Foo)
+
+	values, err := resilientCache.GetAll("testMap", []string{"written", "read"})
+	assertions.NoError(err)
+	assertions.Len(values, 2)
+
+	_, err = resilientCache.GetAll("testMap", []string{"written", "unknown"})
+	assertions.ErrorIs(err, ErrCacheUnavailable)
+
+	_, err = resilientCache.Get("testMap", "unknown")
+	assertions.ErrorIs(err, ErrCacheUnavailable)
+	assertions.Equal(uint64(2), resilientCache.Stats().StaleReads)
+}
+
+func TestResilientCache_StaleFallbackInvalidation(t *testing.T) {
+	assertions := assert.New(t)
+	backend := newUnavailableCache(0, hzerrors.ErrClientOffline)
+	defer backend.Close()
+
+	resilientCache := NewResilientCache[TestDummy](backend,
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithStaleFallback(time.Minute),
+		WithStaleMaxEntries(2),
+	)
+
+	for _, key := range []string{"first", "second", "third"} {
+		assertions.NoError(resilientCache.Put("testMap", key, TestDummy{Foo: key}))
+	}
+	assertions.Equal(2, resilientCache.lru.Len())
+
+	assertions.NoError(resilientCache.PutWithTTL("testMap", "second", TestDummy{Foo: "expiring"}, time.Minute))
+
+	assertions.NoError(backend.MemoryCache.Delete("testMap", "third"))
+	values, err := resilientCache.GetAll("testMap", []string{"third"})
+	assertions.NoError(err)
+	assertions.Empty(values)
+
+	backend.failures.Store(10)
+	for _, key := range []string{"first", "second", "third"} {
+		_, err := resilientCache.Get("testMap", key)
+		assertions.ErrorIs(err, ErrCacheUnavailable, key)
+	}
+	assertions.Empty(resilientCache.local)
+}
+
+// slowCache delays every Get until the delay passed or the context is done.
+type slowCache[T any] struct {
+	Cache[T]
+	delay time.Duration
+}
+
+func (c *slowCache[T]) GetCtx(ctx context.Context, mapName string, key string) (*T, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.delay):
+		return c.Cache.GetCtx(ctx, mapName, key)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+)
+
+// importBatchSize is the number of entries written at once by Import.
+const importBatchSize = 100
+
+// ImportMode defines how Import handles entries that already exist in the target map.
+type ImportMode int
+
+const (
+	// ImportMerge writes all imported entries and keeps the entries that are not part of the snapshot.
+	ImportMerge ImportMode = iota
+	// ImportOverwrite writes all imported entries and removes the entries that are not part of the snapshot.
+	ImportOverwrite
+	// ImportSkipExisting only writes the imported entries whose key does not exist yet.
+	ImportSkipExisting
+)
+
+// ErrImportModeNotSupported is returned by Import if the cache lacks the capabilities required by the mode.
+var ErrImportModeNotSupported = errors.New("import mode is not supported by the cache")
+
+// SnapshotEntry is a single line of a snapshot written by Export.
+type SnapshotEntry[T any] struct {
+	Key   string `json:"key"`
+	Value T      `json:"value"`
+}
+
+// ImportResult summarizes an Import.
+type ImportResult struct {
+	Imported int
+	Skipped  int
+	Removed  int
+}
+
+// Export writes the entries of a map matching query as newline-delimited JSON to w and returns the number of entries.
+// All entries are exported if query is nil. The entries are fetched page by page, so the snapshot is not atomic.
+func Export[T any](ctx context.Context, c QueryableCache[T], mapName string, w io.Writer, query predicate.Predicate) (int, error) {
+	if query == nil {
+		query = predicate.True()
+	}
+
+	seq, iterErr := c.QueryIterCtx(ctx, mapName, query, DefaultQueryPageSize)
+	encoder := json.NewEncoder(w)
+
+	var exported int
+	for key, value := range seq {
+		if err := encoder.Encode(SnapshotEntry[T]{Key: key, Value: value}); err != nil {
+			return exported, fmt.Errorf("failed to write entry with key '%s': %w", key, err)
+		}
+		exported++
+	}
+
+	if err := iterErr(); err != nil {
+		return exported, fmt.Errorf("failed to export map '%s': %w", mapName, err)
+	}
+
+	return exported, nil
+}
+
+// Import reads newline-delimited JSON written by Export from r and writes the entries to a map.
+// ImportOverwrite requires a QueryableCache to find the entries to remove. ImportSkipExisting uses PutIfAbsent
+// of a ConcurrentCache if available and otherwise checks for existing entries, which is prone to races.
+// Entries are written in batches, so an error may leave the map partially imported.
+func Import[T any](ctx context.Context, c Cache[T], mapName string, r io.Reader, mode ImportMode) (ImportResult, error) {
+	var existing []string
+	if mode == ImportOverwrite {
+		queryableCache, ok := c.(QueryableCache[T])
+		if !ok {
+			return ImportResult{}, ErrImportModeNotSupported
+		}
+
+		var err error
+		if existing, err = queryableCache.GetQueryKeysCtx(ctx, mapName, predicate.True()); err != nil {
+			return ImportResult{}, err
+		}
+	}
+
+	imp := &importer[T]{
+		ctx:      ctx,
+		cache:    c,
+		mapName:  mapName,
+		mode:     mode,
+		imported: make(map[string]struct{}),
+		batch:    make(map[string]T, importBatchSize),
+	}
+
+	decoder := json.NewDecoder(r)
+	for line := 1; ; line++ {
+		var entry SnapshotEntry[T]
+		if err := decoder.Decode(&entry); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return imp.result, fmt.Errorf("invalid snapshot entry %d: %w", line, err)
+		}
+
+		if err := imp.add(entry); err != nil {
+			return imp.result, err
+		}
+	}
+
+	if err := imp.flush(); err != nil {
+		return imp.result, err
+	}
+
+	return imp.result, imp.removeMissing(existing)
+}
+
+// importer writes the entries of a snapshot to a cache.
+type importer[T any] struct {
+	ctx      context.Context
+	cache    Cache[T]
+	mapName  string
+	mode     ImportMode
+	result   ImportResult
+	imported map[string]struct{}
+	batch    map[string]T
+}
+
+func (i *importer[T]) add(entry SnapshotEntry[T]) error {
+	if i.mode == ImportOverwrite {
+		i.imported[entry.Key] = struct{}{}
+	}
+
+	if i.mode == ImportSkipExisting {
+		stored, err := putIfAbsent(i.ctx, i.cache, i.mapName, entry.Key, entry.Value)
+		if err != nil {
+			return err
+		}
+
+		if stored {
+			i.result.Imported++
+		} else {
+			i.result.Skipped++
+		}
+		return nil
+	}
+
+	i.batch[entry.Key] = entry.Value
+	if len(i.batch) >= importBatchSize {
+		return i.flush()
+	}
+
+	return nil
+}
+
+func (i *importer[T]) flush() error {
+	if len(i.batch) == 0 {
+		return nil
+	}
+
+	if err := i.cache.PutAllCtx(i.ctx, i.mapName, i.batch); err != nil {
+		return err
+	}
+
+	i.result.Imported += len(i.batch)
+	clear(i.batch)
+	return nil
+}
+
+// removeMissing deletes the given keys unless they have been imported.
+func (i *importer[T]) removeMissing(keys []string) error {
+	removals := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := i.imported[key]; !ok {
+			removals = append(removals, key)
+		}
+	}
+
+	if len(removals) == 0 {
+		return nil
+	}
+
+	if err := i.cache.DeleteAllCtx(i.ctx, i.mapName, removals); err != nil {
+		return err
+	}
+
+	i.result.Removed = len(removals)
+	return nil
+}
+
+func putIfAbsent[T any](ctx context.Context, c Cache[T], mapName string, key string, value T) (bool, error) {
+	if concurrentCache, ok := c.(ConcurrentCache[T]); ok {
+		existing, err := concurrentCache.PutIfAbsentCtx(ctx, mapName, key, value)
+		return existing == nil && err == nil, err
+	}
+
+	existing, err := c.GetCtx(ctx, mapName, key)
+	if errors.Is(err, ErrNotFound) {
+		existing, err = nil, nil
+	}
+
+	if err != nil || existing != nil {
+		return false, err
+	}
+
+	return true, c.PutCtx(ctx, mapName, key, value)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	memoryCache := NewMemoryCache[TestDummy]()
+	assertions.NoError(memoryCache.PutAll("testMap", map[string]TestDummy{"a": {Foo: "bar"}, "b": {Foo: "baz"}}))
+
+	var buffer bytes.Buffer
+	exported, err := Export(ctx, memoryCache, "testMap", &buffer, predicate.Equal("foo", "bar"))
+	assertions.NoError(err)
+	assertions.Equal(1, exported)
+	assertions.JSONEq(`{"key":"a","value":{"foo":"bar"}}`, buffer.String())
+
+	buffer.Reset()
+	exported, err = Export(ctx, memoryCache, "testMap", &buffer, nil)
+	assertions.NoError(err)
+	assertions.Equal(2, exported)
+	assertions.Len(strings.Split(strings.TrimSpace(buffer.String()), "\n"), 2)
+}
+
+func TestImport(t *testing.T) {
+	snapshot := `{"key":"a","value":{"foo":"imported"}}
+{"key":"c","value":{"foo":"imported"}}
+`
+
+	var tests = []struct {
+		name     string
+		mode     ImportMode
+		expected map[string]TestDummy
+		result   ImportResult
+	}{
+		{
+			name:     "merge",
+			mode:     ImportMerge,
+			expected: map[string]TestDummy{"a": {Foo: "imported"}, "b": {Foo: "existing"}, "c": {Foo: "imported"}},
+			result:   ImportResult{Imported: 2},
+		},
+		{
+			name:     "overwrite",
+			mode:     ImportOverwrite,
+			expected: map[string]TestDummy{"a": {Foo: "imported"}, "c": {Foo: "imported"}},
+			result:   ImportResult{Imported: 2, Removed: 1},
+		},
+		{
+			name:     "skip existing",
+			mode:     ImportSkipExisting,
+			expected: map[string]TestDummy{"a": {Foo: "existing"}, "b": {Foo: "existing"}, "c": {Foo: "imported"}},
+			result:   ImportResult{Imported: 1, Skipped: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertions := assert.New(t)
+			ctx := context.Background()
+
+			memoryCache := NewMemoryCache[TestDummy]()
+			existing := map[string]TestDummy{"a": {Foo: "existing"}, "b": {Foo: "existing"}}
+			assertions.NoError(memoryCache.PutAll("testMap", existing))
+
+			result, err := Import[TestDummy](ctx, memoryCache, "testMap", strings.NewReader(snapshot), tt.mode)
+			assertions.NoError(err)
+			assertions.Equal(tt.result, result)
+
+			values, err := memoryCache.GetAll("testMap", []string{"a", "b", "c"})
+			assertions.NoError(err)
+			assertions.Equal(tt.expected, values)
+		})
+	}
+
+	t.Run("invalid entry", func(t *testing.T) {
+		assertions := assert.New(t)
+
+		memoryCache := NewMemoryCache[TestDummy]()
+		reader := strings.NewReader(snapshot + "{invalid\n")
+
+		result, err := Import[TestDummy](context.Background(), memoryCache, "testMap", reader, ImportMerge)
+		assertions.ErrorContains(err, "invalid snapshot entry 3")
+		assertions.Zero(result.Imported)
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Store is the persistent backing store of a PersistentCache, e.g. a database collection per map.
+// Load returns nil if the value does not exist, LoadAll only contains the keys that exist.
+type Store[T any] interface {
+	Load(ctx context.Context, mapName string, key string) (*T, error)
+	LoadAll(ctx context.Context, mapName string, keys []string) (map[string]T, error)
+	Store(ctx context.Context, mapName string, key string, value T) error
+	Delete(ctx context.Context, mapName string, key string) error
+}
+
+// MemoryStore is an in-process Store, mainly intended for tests.
+type MemoryStore[T any] struct {
+	mu   sync.RWMutex
+	maps map[string]map[string]T
+}
+
+var _ Store[any] = (*MemoryStore[any])(nil)
+
+// NewMemoryStore creates a new empty MemoryStore.
+func NewMemoryStore[T any]() *MemoryStore[T] {
+	return &MemoryStore[T]{maps: make(map[string]map[string]T)}
+}
+
+func (s *MemoryStore[T]) Load(ctx context.Context, mapName string, key string) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, ok := s.maps[mapName][key]
+	if !ok {
+		//nolint:nilnil // A missing value is a valid state, not an error
+		return nil, nil
+	}
+
+	return &value, nil
+}
+
+func (s *MemoryStore[T]) LoadAll(ctx context.Context, mapName string, keys []string) (map[string]T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	values := make(map[string]T, len(keys))
+	for _, key := range keys {
+		if value, ok := s.maps[mapName][key]; ok {
+			values[key] = value
+		}
+	}
+
+	return values, nil
+}
+
+func (s *MemoryStore[T]) Store(ctx context.Context, mapName string, key string, value T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mp, ok := s.maps[mapName]
+	if !ok {
+		mp = make(map[string]T)
+		s.maps[mapName] = mp
+	}
+	mp[key] = value
+
+	return nil
+}
+
+func (s *MemoryStore[T]) Delete(ctx context.Context, mapName string, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.maps[mapName], key)
+
+	return nil
+}
+
+// Keys returns the sorted keys stored for the given map.
+func (s *MemoryStore[T]) Keys(mapName string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Sorted(maps.Keys(s.maps[mapName]))
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+
+	"github.com/telekom/pubsub-horizon-go/cache"
+	"github.com/telekom/pubsub-horizon-go/cache/query"
+	"github.com/telekom/pubsub-horizon-go/enum"
+	"github.com/telekom/pubsub-horizon-go/message"
+)
+
+// CircuitBreakerMapName is the name of the map holding the circuit breakers.
+const CircuitBreakerMapName = "circuit-breakers"
+
+// CircuitBreakerRepository provides access to circuit breakers, which are stored by the ID of their subscription.
+type CircuitBreakerRepository struct {
+	repository[message.CircuitBreakerMessage]
+}
+
+// NewCircuitBreakerRepository creates a CircuitBreakerRepository. Queries require a cache.QueryableCache.
+func NewCircuitBreakerRepository(c cache.Cache[message.CircuitBreakerMessage]) *CircuitBreakerRepository {
+	return &CircuitBreakerRepository{repository[message.CircuitBreakerMessage]{
+		cache:   c,
+		mapName: CircuitBreakerMapName,
+		key: func(value *message.CircuitBreakerMessage) string {
+			return value.SubscriptionId
+		},
+	}}
+}
+
+// FindByStatus returns all circuit breakers with the given status.
+func (r *CircuitBreakerRepository) FindByStatus(
+	ctx context.Context,
+	status enum.CircuitBreakerStatus,
+) ([]message.CircuitBreakerMessage, error) {
+	return r.FindWhere(ctx, query.Equal("Status", status))
+}
+
+// FindByEnvironment returns all circuit breakers of the given environment.
+func (r *CircuitBreakerRepository) FindByEnvironment(ctx context.Context, environment string) ([]message.CircuitBreakerMessage, error) {
+	return r.FindWhere(ctx, query.Equal("Environment", environment))
+}
+
+// FindByEventType returns all circuit breakers of subscriptions for the given event type.
+func (r *CircuitBreakerRepository) FindByEventType(ctx context.Context, eventType string) ([]message.CircuitBreakerMessage, error) {
+	return r.FindWhere(ctx, query.Equal("EventType", eventType))
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package repository provides typed access to the Horizon maps, encapsulating their names, keys and common queries.
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/telekom/pubsub-horizon-go/cache"
+	"github.com/telekom/pubsub-horizon-go/cache/query"
+)
+
+var (
+	// ErrMissingKey is returned when saving a value whose key cannot be derived.
+	ErrMissingKey = errors.New("value has no key")
+	// ErrQueryNotSupported is returned by queries if the underlying cache is not a cache.QueryableCache.
+	ErrQueryNotSupported = errors.New("cache does not support queries")
+)
+
+// repository implements the operations shared by all typed repositories.
+type repository[T any] struct {
+	cache   cache.Cache[T]
+	mapName string
+	key     func(value *T) string
+}
+
+// MapName returns the name of the map the repository operates on.
+func (r *repository[T]) MapName() string {
+	return r.mapName
+}
+
+// Get returns the value with the given ID or nil if it does not exist.
+func (r *repository[T]) Get(ctx context.Context, id string) (*T, error) {
+	return r.cache.GetCtx(ctx, r.mapName, id)
+}
+
+// Save stores a value under the key derived from it.
+func (r *repository[T]) Save(ctx context.Context, value T) error {
+	key := r.key(&value)
+	if key == "" {
+		return ErrMissingKey
+	}
+
+	return r.cache.PutCtx(ctx, r.mapName, key, value)
+}
+
+// Delete removes the value with the given ID.
+func (r *repository[T]) Delete(ctx context.Context, id string) error {
+	return r.cache.DeleteCtx(ctx, r.mapName, id)
+}
+
+// All returns all values of the map.
+func (r *repository[T]) All(ctx context.Context) ([]T, error) {
+	return r.Find(ctx, predicate.True())
+}
+
+// Find returns all values matching the given predicate.
+func (r *repository[T]) Find(ctx context.Context, filter predicate.Predicate) ([]T, error) {
+	queryableCache, ok := r.cache.(cache.QueryableCache[T])
+	if !ok {
+		return nil, ErrQueryNotSupported
+	}
+
+	return queryableCache.GetQueryCtx(ctx, r.mapName, filter)
+}
+
+// FindWhere returns all values satisfying the given conditions on the fields of T.
+func (r *repository[T]) FindWhere(ctx context.Context, conditions ...query.Condition) ([]T, error) {
+	q, err := query.New[T]().Where(conditions...).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Find(ctx, q.Predicate())
+}
+
+// Watch registers a listener that is notified about all changes of the map.
+func (r *repository[T]) Watch(
+	ctx context.Context,
+	listener cache.EventListener[T],
+	opts ...cache.ListenerOption,
+) (cache.ListenerHandle, error) {
+	return r.cache.AddListenerCtx(ctx, r.mapName, cache.NewEventListenerAdapter(listener), opts...)
+}
+
+// WatchID registers a listener that is only notified about changes of the value with the given ID.
+func (r *repository[T]) WatchID(
+	ctx context.Context,
+	id string,
+	listener cache.EventListener[T],
+	opts ...cache.ListenerOption,
+) (cache.ListenerHandle, error) {
+	return r.cache.AddListenerForKeyCtx(ctx, r.mapName, id, cache.NewEventListenerAdapter(listener), opts...)
+}
+
+// Unwatch removes a listener registered using Watch or WatchID.
+func (r *repository[T]) Unwatch(ctx context.Context, handle cache.ListenerHandle) error {
+	return r.cache.RemoveListenerCtx(ctx, handle)
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/pubsub-horizon-go/cache"
+	"github.com/telekom/pubsub-horizon-go/enum"
+	"github.com/telekom/pubsub-horizon-go/message"
+	"github.com/telekom/pubsub-horizon-go/resource"
+)
+
+func TestSubscriptionRepository(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	repository := NewSubscriptionRepository(cache.NewMemoryCache[resource.SubscriptionResource]())
+	assertions.Equal(SubscriptionMapName, repository.MapName())
+
+	for _, subscription := range []resource.SubscriptionResource{
+		newSubscription("a", "integration", "subscriber-1", "de.telekom.foo.v1"),
+		newSubscription("b", "integration", "subscriber-2", "de.telekom.bar.v1"),
+		newSubscription("c", "playground", "subscriber-1", "de.telekom.foo.v1"),
+	} {
+		assertions.NoError(repository.Save(ctx, subscription))
+	}
+
+	assertions.ErrorIs(repository.Save(ctx, newSubscription("", "integration", "subscriber-3", "de.telekom.foo.v1")), ErrMissingKey)
+
+	subscription, err := repository.Get(ctx, "a")
+	assertions.NoError(err)
+	assertions.Equal("subscriber-1", subscription.Spec.Subscription.SubscriberId)
+
+	assertions.ElementsMatch([]string{"a", "b"}, subscriptionIDs(repository.FindByEnvironment(ctx, "integration")))
+	assertions.ElementsMatch([]string{"a", "c"}, subscriptionIDs(repository.FindBySubscriber(ctx, "subscriber-1")))
+	assertions.ElementsMatch([]string{"a", "c"}, subscriptionIDs(repository.FindByEventType(ctx, "de.telekom.foo.v1")))
+	assertions.ElementsMatch([]string{"c"}, subscriptionIDs(repository.FindByEnvironmentAndEventType(ctx, "playground", "de.telekom.foo.v1")))
+
+	assertions.NoError(repository.Delete(ctx, "a"))
+	assertions.ElementsMatch([]string{"b", "c"}, subscriptionIDs(repository.All(ctx)))
+}
+
+func TestCircuitBreakerRepository(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	repository := NewCircuitBreakerRepository(cache.NewMemoryCache[message.CircuitBreakerMessage]())
+
+	listener := &recordingListener{}
+	handle, err := repository.Watch(ctx, listener)
+	assertions.NoError(err)
+
+	assertions.NoError(repository.Save(ctx, message.CircuitBreakerMessage{
+		SubscriptionId: "a",
+		Environment:    "integration",
+		EventType:      "de.telekom.foo.v1",
+		Status:         enum.CircuitBreakerStatusOpen,
+	}))
+	assertions.NoError(repository.Save(ctx, message.CircuitBreakerMessage{
+		SubscriptionId: "b",
+		Environment:    "playground",
+		EventType:      "de.telekom.bar.v1",
+		Status:         enum.CircuitBreakerStatusClosed,
+	}))
+
+	open, err := repository.FindByStatus(ctx, enum.CircuitBreakerStatusOpen)
+	assertions.NoError(err)
+	if assertions.Len(open, 1) {
+		assertions.Equal("a", open[0].SubscriptionId)
+	}
+
+	playground, err := repository.FindByEnvironment(ctx, "playground")
+	assertions.NoError(err)
+	assertions.Len(playground, 1)
+
+	foo, err := repository.FindByEventType(ctx, "de.telekom.foo.v1")
+	assertions.NoError(err)
+	assertions.Len(foo, 1)
+
+	assertions.Eventually(func() bool {
+		return len(listener.keys()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assertions.NoError(repository.Unwatch(ctx, handle))
+}
+
+func TestStatusRepository(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	repository := NewStatusRepository(cache.NewMemoryCache[message.StatusMessage]())
+	assertions.Equal(StatusMapName, repository.MapName())
+
+	for _, status := range []message.StatusMessage{
+		newStatus("a", "subscription-1", enum.StatusWaiting),
+		newStatus("b", "subscription-1", enum.StatusDelivered),
+		newStatus("c", "subscription-2", enum.StatusWaiting),
+	} {
+		assertions.NoError(repository.Save(ctx, status))
+	}
+
+	assertions.ErrorIs(repository.Save(ctx, newStatus("", "subscription-1", enum.StatusWaiting)), ErrMissingKey)
+
+	status, err := repository.Get(ctx, "b")
+	assertions.NoError(err)
+	assertions.Equal(enum.StatusDelivered, status.Status)
+
+	assertions.ElementsMatch([]string{"a", "c"}, statusIDs(repository.FindByStatus(ctx, enum.StatusWaiting)))
+	assertions.ElementsMatch([]string{"a", "b"}, statusIDs(repository.FindBySubscription(ctx, "subscription-1")))
+	assertions.ElementsMatch([]string{"a"}, statusIDs(repository.FindBySubscriptionAndStatus(ctx, "subscription-1", enum.StatusWaiting)))
+}
+
+func TestRepository_QueryNotSupported(t *testing.T) {
+	repository := NewCircuitBreakerRepository(cache.NewNearCache[message.CircuitBreakerMessage](
+		cache.NewMemoryCache[message.CircuitBreakerMessage](),
+	))
+
+	_, err := repository.FindByStatus(context.Background(), enum.CircuitBreakerStatusOpen)
+	assert.ErrorIs(t, err, ErrQueryNotSupported)
+}
+
+func newSubscription(id string, environment string, subscriberID string, eventType string) resource.SubscriptionResource {
+	var subscription resource.SubscriptionResource
+	subscription.Spec.Environment = environment
+	subscription.Spec.Subscription.SubscriptionId = id
+	subscription.Spec.Subscription.SubscriberId = subscriberID
+	subscription.Spec.Subscription.Type = eventType
+	subscription.Spec.Subscription.DeliveryType = enum.DeliveryTypeCallback
+	subscription.Spec.Subscription.Trigger.ResponseFilterMode = enum.ResponseFilterModeInclude
+	subscription.Spec.Subscription.PublisherTrigger.ResponseFilterMode = enum.ResponseFilterModeInclude
+	return subscription
+}
+
+func subscriptionIDs(subscriptions []resource.SubscriptionResource, err error) []string {
+	if err != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.Spec.Subscription.SubscriptionId)
+	}
+	return ids
+}
+
+func newStatus(id string, subscriptionID string, status enum.MessageStatus) message.StatusMessage {
+	return message.StatusMessage{
+		Uuid:               id,
+		SubscriptionId:     subscriptionID,
+		Status:             status,
+		DeliveryType:       enum.DeliveryTypeCallback,
+		EventRetentionTime: enum.TtlDefault,
+	}
+}
+
+func statusIDs(statuses []message.StatusMessage, err error) []string {
+	if err != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		ids = append(ids, status.Uuid)
+	}
+	return ids
+}
+
+type recordingListener struct {
+	mu     sync.Mutex
+	events []cache.Event
+}
+
+func (l *recordingListener) OnAdd(event cache.Event, _ message.CircuitBreakerMessage) {
+	l.record(event)
+}
+
+func (l *recordingListener) OnUpdate(event cache.Event, _ message.CircuitBreakerMessage, _ message.CircuitBreakerMessage) {
+	l.record(event)
+}
+
+func (l *recordingListener) OnDelete(event cache.Event, _ *message.CircuitBreakerMessage) {
+	l.record(event)
+}
+
+func (l *recordingListener) OnClear(event cache.Event) {
+	l.record(event)
+}
+
+func (l *recordingListener) OnError(event cache.Event, _ error) {
+	l.record(event)
+}
+
+func (l *recordingListener) record(event cache.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *recordingListener) keys() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	keys := make([]string, 0, len(l.events))
+	for _, event := range l.events {
+		keys = append(keys, event.Key)
+	}
+	return keys
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+
+	"github.com/telekom/pubsub-horizon-go/cache"
+	"github.com/telekom/pubsub-horizon-go/cache/query"
+	"github.com/telekom/pubsub-horizon-go/enum"
+	"github.com/telekom/pubsub-horizon-go/message"
+)
+
+// StatusMapName is the name of the map holding the status entries of messages.
+const StatusMapName = "status"
+
+// StatusRepository provides access to the status entries of messages, which are stored by their UUID.
+type StatusRepository struct {
+	repository[message.StatusMessage]
+}
+
+// NewStatusRepository creates a StatusRepository. Queries require a cache.QueryableCache.
+func NewStatusRepository(c cache.Cache[message.StatusMessage]) *StatusRepository {
+	return &StatusRepository{repository[message.StatusMessage]{
+		cache:   c,
+		mapName: StatusMapName,
+		key: func(value *message.StatusMessage) string {
+			return value.Uuid
+		},
+	}}
+}
+
+// FindByStatus returns all status entries with the given status.
+func (r *StatusRepository) FindByStatus(ctx context.Context, status enum.MessageStatus) ([]message.StatusMessage, error) {
+	return r.FindWhere(ctx, query.Equal("Status", status))
+}
+
+// FindBySubscription returns all status entries of the given subscription.
+func (r *StatusRepository) FindBySubscription(ctx context.Context, subscriptionID string) ([]message.StatusMessage, error) {
+	return r.FindWhere(ctx, query.Equal("SubscriptionId", subscriptionID))
+}
+
+// FindBySubscriptionAndStatus returns all status entries of the given subscription with the given status.
+func (r *StatusRepository) FindBySubscriptionAndStatus(
+	ctx context.Context,
+	subscriptionID string,
+	status enum.MessageStatus,
+) ([]message.StatusMessage, error) {
+	return r.FindWhere(ctx,
+		query.Equal("SubscriptionId", subscriptionID),
+		query.Equal("Status", status),
+	)
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+
+	"github.com/telekom/pubsub-horizon-go/cache"
+	"github.com/telekom/pubsub-horizon-go/cache/query"
+	"github.com/telekom/pubsub-horizon-go/resource"
+)
+
+// SubscriptionMapName is the name of the map holding the subscription resources.
+const SubscriptionMapName = "subscriptions.subscriber.horizon.telekom.de.v1"
+
+// SubscriptionRepository provides access to subscription resources, which are stored by their subscription ID.
+type SubscriptionRepository struct {
+	repository[resource.SubscriptionResource]
+}
+
+// NewSubscriptionRepository creates a SubscriptionRepository. Queries require a cache.QueryableCache.
+func NewSubscriptionRepository(c cache.Cache[resource.SubscriptionResource]) *SubscriptionRepository {
+	return &SubscriptionRepository{repository[resource.SubscriptionResource]{
+		cache:   c,
+		mapName: SubscriptionMapName,
+		key: func(value *resource.SubscriptionResource) string {
+			return value.Spec.Subscription.SubscriptionId
+		},
+	}}
+}
+
+// FindByEnvironment returns all subscriptions of the given environment.
+func (r *SubscriptionRepository) FindByEnvironment(ctx context.Context, environment string) ([]resource.SubscriptionResource, error) {
+	return r.FindWhere(ctx, query.Equal("Spec.Environment", environment))
+}
+
+// FindBySubscriber returns all subscriptions of the given subscriber.
+func (r *SubscriptionRepository) FindBySubscriber(ctx context.Context, subscriberID string) ([]resource.SubscriptionResource, error) {
+	return r.FindWhere(ctx, query.Equal("Spec.Subscription.SubscriberId", subscriberID))
+}
+
+// FindByEventType returns all subscriptions for the given event type.
+func (r *SubscriptionRepository) FindByEventType(ctx context.Context, eventType string) ([]resource.SubscriptionResource, error) {
+	return r.FindWhere(ctx, query.Equal("Spec.Subscription.Type", eventType))
+}
+
+// FindByEnvironmentAndEventType returns all subscriptions for the given event type within an environment.
+func (r *SubscriptionRepository) FindByEnvironmentAndEventType(
+	ctx context.Context,
+	environment string,
+	eventType string,
+) ([]resource.SubscriptionResource, error) {
+	return r.FindWhere(ctx,
+		query.Equal("Spec.Environment", environment),
+		query.Equal("Spec.Subscription.Type", eventType),
+	)
+}
@@ -9,6 +9,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -32,19 +33,32 @@ func NewTraceContext(ctx context.Context, service string, detailed bool) *TraceC
 	}
 }
 
-// StartSpan starts a new span.
+// StartSpan starts a new span. Options like trace.WithSpanKind, trace.WithLinks and trace.WithTimestamp are passed to the tracer.
 //
 //nolint:spancheck // Span lifecycle is managed by TraceContext; ended via EndCurrentSpan()
-func (c *TraceContext) StartSpan(name string) {
+func (c *TraceContext) StartSpan(name string, opts ...trace.SpanStartOption) {
 	var span trace.Span
-	c.traceCtx, span = c.tracer.Start(c.traceCtx, name)
+	c.traceCtx, span = c.tracer.Start(c.traceCtx, name, opts...)
 	c.spans = append(c.spans, span)
 }
 
+// StartScopedSpan starts a new span and returns a function that ends it, meant to be deferred.
+// Ending the span restores the context of its parent, so spans started afterward are siblings instead of children.
+func (c *TraceContext) StartScopedSpan(name string, opts ...trace.SpanStartOption) (end func()) {
+	parent := c.traceCtx
+	c.StartSpan(name, opts...)
+	span := c.LastSpan()
+
+	return func() {
+		span.End()
+		c.traceCtx = parent
+	}
+}
+
 // StartDetailedSpan starts a span that will only be started if detailed tracing is enabled.
-func (c *TraceContext) StartDetailedSpan(name string) {
+func (c *TraceContext) StartDetailedSpan(name string, opts ...trace.SpanStartOption) {
 	if c.detailed {
-		c.StartSpan(name)
+		c.StartSpan(name, opts...)
 	}
 }
 
@@ -66,8 +80,77 @@ func (c *TraceContext) EndCurrentDetailedSpan() {
 
 // SetAttribute sets the value of the given key.
 func (c *TraceContext) SetAttribute(key string, value string) {
+	c.SetAttributes(attribute.String(key, value))
+}
+
+// SetIntAttribute sets the int value of the given key.
+func (c *TraceContext) SetIntAttribute(key string, value int) {
+	c.SetAttributes(attribute.Int(key, value))
+}
+
+// SetInt64Attribute sets the int64 value of the given key.
+func (c *TraceContext) SetInt64Attribute(key string, value int64) {
+	c.SetAttributes(attribute.Int64(key, value))
+}
+
+// SetBoolAttribute sets the bool value of the given key.
+func (c *TraceContext) SetBoolAttribute(key string, value bool) {
+	c.SetAttributes(attribute.Bool(key, value))
+}
+
+// SetFloatAttribute sets the float64 value of the given key.
+func (c *TraceContext) SetFloatAttribute(key string, value float64) {
+	c.SetAttributes(attribute.Float64(key, value))
+}
+
+// SetStringSliceAttribute sets the string slice value of the given key.
+func (c *TraceContext) SetStringSliceAttribute(key string, value []string) {
+	c.SetAttributes(attribute.StringSlice(key, value))
+}
+
+// SetIntSliceAttribute sets the int slice value of the given key.
+func (c *TraceContext) SetIntSliceAttribute(key string, value []int) {
+	c.SetAttributes(attribute.IntSlice(key, value))
+}
+
+// SetAttributes sets the given attributes on the current span.
+func (c *TraceContext) SetAttributes(attributes ...attribute.KeyValue) {
 	if currentSpan := c.CurrentSpan(); currentSpan != nil {
-		currentSpan.SetAttributes(attribute.String(key, value))
+		currentSpan.SetAttributes(attributes...)
+	}
+}
+
+// RecordError records err as an exception event of the current span and marks the span as failed.
+// Nothing is recorded if err is nil.
+func (c *TraceContext) RecordError(err error, attributes ...attribute.KeyValue) {
+	if err == nil {
+		return
+	}
+
+	if currentSpan := c.CurrentSpan(); currentSpan != nil {
+		currentSpan.RecordError(err, trace.WithAttributes(attributes...))
+		currentSpan.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// SetStatus sets the status of the current span. The description is only used for codes.Error.
+func (c *TraceContext) SetStatus(code codes.Code, description string) {
+	if currentSpan := c.CurrentSpan(); currentSpan != nil {
+		currentSpan.SetStatus(code, description)
+	}
+}
+
+// AddEvent adds an event with the given attributes to the current span.
+func (c *TraceContext) AddEvent(name string, attributes ...attribute.KeyValue) {
+	if currentSpan := c.CurrentSpan(); currentSpan != nil {
+		currentSpan.AddEvent(name, trace.WithAttributes(attributes...))
+	}
+}
+
+// AddLink links the current span to another span, e.g. the span of the message that caused it.
+func (c *TraceContext) AddLink(link trace.Link) {
+	if currentSpan := c.CurrentSpan(); currentSpan != nil {
+		currentSpan.AddLink(link)
 	}
 }
 
@@ -108,3 +191,16 @@ func (c *TraceContext) LastSpan() trace.Span {
 func (c *TraceContext) Context() context.Context {
 	return c.traceCtx
 }
+
+type traceContextKey struct{}
+
+// WithTraceContext returns a copy of ctx that carries the given TraceContext.
+func WithTraceContext(ctx context.Context, traceCtx *TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, traceCtx)
+}
+
+// FromContext returns the TraceContext carried by ctx or nil if there is none.
+func FromContext(ctx context.Context) *TraceContext {
+	traceCtx, _ := ctx.Value(traceContextKey{}).(*TraceContext)
+	return traceCtx
+}
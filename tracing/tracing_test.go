@@ -6,10 +6,14 @@ package tracing
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestTraceContext_StartSpan(t *testing.T) {
@@ -62,3 +66,92 @@ func TestTraceContext_StartDetailedSpan(t *testing.T) {
 		assertions.Equal(0, len(snapshots))
 	})
 }
+
+func TestTraceContext_StartScopedSpan(t *testing.T) {
+	assertions := assert.New(t)
+	traceCtx := NewTraceContext(context.Background(), "myservice", false)
+	defer traceExporter.Reset()
+
+	start := time.Now().Add(-time.Minute)
+	traceCtx.StartSpan("parent")
+	func() {
+		defer traceCtx.StartScopedSpan("first", trace.WithSpanKind(trace.SpanKindClient), trace.WithTimestamp(start))()
+	}()
+	func() {
+		defer traceCtx.StartScopedSpan("second")()
+	}()
+	traceCtx.EndCurrentSpan()
+
+	snapshots := traceExporter.GetSpans().Snapshots()
+	assertions.Len(snapshots, 3)
+
+	first, second, parent := snapshots[0], snapshots[1], snapshots[2]
+	assertions.Equal("first", first.Name())
+	assertions.Equal(trace.SpanKindClient, first.SpanKind())
+	assertions.True(first.StartTime().Equal(start))
+	assertions.Equal(parent.SpanContext().SpanID(), first.Parent().SpanID())
+	assertions.Equal(parent.SpanContext().SpanID(), second.Parent().SpanID())
+	assertions.Equal(2, parent.ChildSpanCount())
+}
+
+func TestTraceContext_SpanData(t *testing.T) {
+	assertions := assert.New(t)
+	traceCtx := NewTraceContext(context.Background(), "myservice", false)
+	defer traceExporter.Reset()
+
+	linked := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1},
+		SpanID:  trace.SpanID{1},
+	})
+
+	traceCtx.StartSpan("myspan", trace.WithLinks(trace.Link{SpanContext: linked}))
+	traceCtx.AddLink(trace.Link{SpanContext: linked.WithSpanID(trace.SpanID{2})})
+	traceCtx.SetIntAttribute("retries", 3)
+	traceCtx.SetInt64Attribute("offset", 42)
+	traceCtx.SetBoolAttribute("redelivered", true)
+	traceCtx.SetFloatAttribute("ratio", 0.5)
+	traceCtx.SetStringSliceAttribute("hosts", []string{"a", "b"})
+	traceCtx.SetIntSliceAttribute("codes", []int{500, 503})
+	traceCtx.AddEvent("callback.sent", attribute.String("url", "http://localhost"))
+	traceCtx.RecordError(nil)
+	traceCtx.RecordError(errors.New("callback failed"), attribute.Int("status", 503))
+	traceCtx.EndCurrentSpan()
+
+	traceCtx.SetStatus(codes.Ok, "")
+	traceCtx.RecordError(errors.New("ignored"))
+
+	snapshots := traceExporter.GetSpans().Snapshots()
+	assertions.Len(snapshots, 1)
+
+	span := snapshots[0]
+	assertions.ElementsMatch([]attribute.KeyValue{
+		attribute.Int("retries", 3),
+		attribute.Int64("offset", 42),
+		attribute.Bool("redelivered", true),
+		attribute.Float64("ratio", 0.5),
+		attribute.StringSlice("hosts", []string{"a", "b"}),
+		attribute.IntSlice("codes", []int{500, 503}),
+	}, span.Attributes())
+
+	assertions.Equal(codes.Error, span.Status().Code)
+	assertions.Equal("callback failed", span.Status().Description)
+
+	assertions.Len(span.Links(), 2)
+	assertions.Equal(linked.SpanID(), span.Links()[0].SpanContext.SpanID())
+	assertions.Equal(trace.SpanID{2}, span.Links()[1].SpanContext.SpanID())
+
+	events := span.Events()
+	assertions.Len(events, 2)
+	assertions.Equal("callback.sent", events[0].Name)
+	assertions.Equal("exception", events[1].Name)
+	assertions.Contains(events[1].Attributes, attribute.Int("status", 503))
+}
+
+func TestWithTraceContext(t *testing.T) {
+	assertions := assert.New(t)
+	traceCtx := NewTraceContext(context.Background(), "myservice", false)
+
+	ctx := WithTraceContext(context.Background(), traceCtx)
+	assertions.Same(traceCtx, FromContext(ctx))
+	assertions.Nil(FromContext(context.Background()))
+}
@@ -5,20 +5,55 @@
 package util
 
 import (
+	"strconv"
+
+	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/hazelcast/hazelcast-go-client/logger"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
+// HazelcastZerologLogger writes the log messages of a Hazelcast client as structured zerolog events
+// with the fields component, weight and cluster. The zero value logs every message using the global logger.
 type HazelcastZerologLogger struct {
-	level zerolog.Level
+	logger  *zerolog.Logger
+	level   zerolog.Level
+	cluster string
+}
+
+// NewHazelcastZerologLogger creates a logger that writes all messages of at least the given level to logger.
+func NewHazelcastZerologLogger(logger zerolog.Logger, level zerolog.Level) *HazelcastZerologLogger {
+	return &HazelcastZerologLogger{logger: &logger, level: level}
+}
+
+// ConfigureHazelcastLogger sets a HazelcastZerologLogger as the custom logger of config.
+// The cluster name of config is added to every message, so it has to be set beforehand.
+// The log level of config is cleared, since Hazelcast does not allow setting it together with a custom logger.
+func ConfigureHazelcastLogger(config *hazelcast.Config, logger zerolog.Logger, level zerolog.Level) {
+	hazelcastLogger := NewHazelcastZerologLogger(logger, level)
+	hazelcastLogger.cluster = config.Cluster.Name
+	config.Logger.CustomLogger = hazelcastLogger
+	config.Logger.Level = ""
 }
 
 func (l *HazelcastZerologLogger) Log(weight logger.Weight, f func() string) {
 	messageLevel := l.translateWeight(weight)
-	if messageLevel >= l.level {
-		log.WithLevel(messageLevel).Msgf("Hazelcast: %s", f())
+	if messageLevel < l.level {
+		return
+	}
+
+	target := l.logger
+	if target == nil {
+		target = &log.Logger
+	}
+
+	event := target.WithLevel(messageLevel).
+		Str("component", "hazelcast").
+		Str("weight", weightName(weight))
+	if l.cluster != "" {
+		event = event.Str("cluster", l.cluster)
 	}
+	event.Msg(f())
 }
 
 func (*HazelcastZerologLogger) translateWeight(weight logger.Weight) zerolog.Level {
@@ -42,3 +77,28 @@ func (*HazelcastZerologLogger) translateWeight(weight logger.Weight) zerolog.Lev
 		return zerolog.InfoLevel
 	}
 }
+
+func weightName(weight logger.Weight) string {
+	switch weight {
+	case logger.WeightTrace:
+		return string(logger.TraceLevel)
+
+	case logger.WeightDebug:
+		return string(logger.DebugLevel)
+
+	case logger.WeightInfo:
+		return string(logger.InfoLevel)
+
+	case logger.WeightWarn:
+		return string(logger.WarnLevel)
+
+	case logger.WeightError:
+		return string(logger.ErrorLevel)
+
+	case logger.WeightFatal:
+		return string(logger.FatalLevel)
+
+	default:
+		return strconv.Itoa(int(weight))
+	}
+}
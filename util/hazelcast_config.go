@@ -0,0 +1,321 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// HazelcastEnvPrefix is the prefix of the environment variables read by LoadEnv.
+const HazelcastEnvPrefix = "HAZELCAST_"
+
+// ErrInvalidHazelcastConfig is returned if a HazelcastConfig is incomplete or contradictory.
+var ErrInvalidHazelcastConfig = errors.New("invalid hazelcast configuration")
+
+// HazelcastConfig is a flat, file- and environment-friendly description of a Hazelcast client configuration.
+// Timeouts of zero keep the defaults of the Hazelcast client.
+type HazelcastConfig struct {
+	ClusterName           string                `yaml:"clusterName"`
+	Addresses             []string              `yaml:"addresses"`
+	SmartRouting          bool                  `yaml:"smartRouting"`
+	ReconnectMode         cluster.ReconnectMode `yaml:"reconnectMode"`
+	FailoverTryCount      int                   `yaml:"failoverTryCount"`
+	ConnectionTimeout     time.Duration         `yaml:"connectionTimeout"`
+	ClusterConnectTimeout time.Duration         `yaml:"clusterConnectTimeout"`
+	InvocationTimeout     time.Duration         `yaml:"invocationTimeout"`
+	HeartbeatInterval     time.Duration         `yaml:"heartbeatInterval"`
+	HeartbeatTimeout      time.Duration         `yaml:"heartbeatTimeout"`
+	Username              string                `yaml:"username"`
+	Password              string                `yaml:"password"`
+	TLS                   HazelcastTLSConfig    `yaml:"tls"`
+	LogLevel              string                `yaml:"logLevel"`
+}
+
+// HazelcastTLSConfig configures TLS connections to the cluster. CAFile is optional and defaults to the system roots.
+type HazelcastTLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"caFile"`
+	CertFile           string `yaml:"certFile"`
+	KeyFile            string `yaml:"keyFile"`
+	ServerName         string `yaml:"serverName"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+}
+
+// NewHazelcastConfig returns the Horizon defaults, which connect to a local cluster named "horizon".
+func NewHazelcastConfig() HazelcastConfig {
+	return HazelcastConfig{
+		ClusterName:      "horizon",
+		Addresses:        []string{"localhost:5701"},
+		SmartRouting:     true,
+		ReconnectMode:    cluster.ReconnectModeOn,
+		FailoverTryCount: 5,
+		LogLevel:         zerolog.InfoLevel.String(),
+	}
+}
+
+// LoadHazelcastConfig builds a Hazelcast configuration from the Horizon defaults, the given YAML or JSON file
+// and the environment, in that order. The file is skipped if path is empty.
+func LoadHazelcastConfig(path string) (hazelcast.Config, error) {
+	config := NewHazelcastConfig()
+	if path != "" {
+		if err := config.LoadFile(path); err != nil {
+			return hazelcast.Config{}, err
+		}
+	}
+
+	if err := config.LoadEnv(HazelcastEnvPrefix); err != nil {
+		return hazelcast.Config{}, err
+	}
+
+	return config.ToHazelcast()
+}
+
+// LoadFile overrides the fields that are set in the given YAML or JSON file. Unknown fields are rejected.
+func (c *HazelcastConfig) LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open hazelcast configuration: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("could not parse hazelcast configuration '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// LoadEnv overrides the fields whose environment variable is set, e.g. HAZELCAST_CLUSTER_NAME for the prefix
+// HazelcastEnvPrefix. Addresses are separated by commas.
+func (c *HazelcastConfig) LoadEnv(prefix string) error {
+	env := envReader{prefix: prefix}
+
+	env.string("CLUSTER_NAME", &c.ClusterName)
+	env.list("ADDRESSES", &c.Addresses)
+	env.bool("SMART_ROUTING", &c.SmartRouting)
+	env.reconnectMode("RECONNECT_MODE", &c.ReconnectMode)
+	env.int("FAILOVER_TRY_COUNT", &c.FailoverTryCount)
+	env.duration("CONNECTION_TIMEOUT", &c.ConnectionTimeout)
+	env.duration("CLUSTER_CONNECT_TIMEOUT", &c.ClusterConnectTimeout)
+	env.duration("INVOCATION_TIMEOUT", &c.InvocationTimeout)
+	env.duration("HEARTBEAT_INTERVAL", &c.HeartbeatInterval)
+	env.duration("HEARTBEAT_TIMEOUT", &c.HeartbeatTimeout)
+	env.string("USERNAME", &c.Username)
+	env.string("PASSWORD", &c.Password)
+	env.bool("TLS_ENABLED", &c.TLS.Enabled)
+	env.string("TLS_CA_FILE", &c.TLS.CAFile)
+	env.string("TLS_CERT_FILE", &c.TLS.CertFile)
+	env.string("TLS_KEY_FILE", &c.TLS.KeyFile)
+	env.string("TLS_SERVER_NAME", &c.TLS.ServerName)
+	env.bool("TLS_INSECURE_SKIP_VERIFY", &c.TLS.InsecureSkipVerify)
+	env.string("LOG_LEVEL", &c.LogLevel)
+
+	return errors.Join(env.errs...)
+}
+
+// Validate checks the configuration and returns all problems at once.
+func (c *HazelcastConfig) Validate() error {
+	var errs []error
+
+	if c.ClusterName == "" {
+		errs = append(errs, errors.New("cluster name must not be empty"))
+	}
+
+	if len(c.Addresses) == 0 {
+		errs = append(errs, errors.New("at least one address is required"))
+	}
+
+	for _, address := range c.Addresses {
+		if strings.TrimSpace(address) == "" {
+			errs = append(errs, errors.New("addresses must not be empty"))
+			break
+		}
+	}
+
+	if c.FailoverTryCount < 0 {
+		errs = append(errs, errors.New("failover try count must not be negative"))
+	}
+
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"connection timeout", c.ConnectionTimeout},
+		{"cluster connect timeout", c.ClusterConnectTimeout},
+		{"invocation timeout", c.InvocationTimeout},
+		{"heartbeat interval", c.HeartbeatInterval},
+		{"heartbeat timeout", c.HeartbeatTimeout},
+	}
+	for _, timeout := range timeouts {
+		if timeout.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", timeout.name))
+		}
+	}
+
+	if c.Password != "" && c.Username == "" {
+		errs = append(errs, errors.New("a password requires a username"))
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("tls certificate and key must be set together"))
+	}
+
+	if _, err := zerolog.ParseLevel(c.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("invalid log level: %w", err))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidHazelcastConfig, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// ToHazelcast validates the configuration and converts it into a Hazelcast client configuration.
+// Log messages of the client are written to the global zerolog logger using HazelcastZerologLogger.
+func (c *HazelcastConfig) ToHazelcast() (hazelcast.Config, error) {
+	if err := c.Validate(); err != nil {
+		return hazelcast.Config{}, err
+	}
+
+	config := hazelcast.NewConfig()
+	config.Cluster.Name = c.ClusterName
+	config.Cluster.Network.SetAddresses(c.Addresses...)
+	config.Cluster.Unisocket = !c.SmartRouting
+	config.Cluster.ConnectionStrategy.ReconnectMode = c.ReconnectMode
+	config.Cluster.ConnectionStrategy.Timeout = types.Duration(c.ClusterConnectTimeout)
+	config.Cluster.Network.ConnectionTimeout = types.Duration(c.ConnectionTimeout)
+	config.Cluster.InvocationTimeout = types.Duration(c.InvocationTimeout)
+	config.Cluster.HeartbeatInterval = types.Duration(c.HeartbeatInterval)
+	config.Cluster.HeartbeatTimeout = types.Duration(c.HeartbeatTimeout)
+	config.Cluster.Security.Credentials.Username = c.Username
+	config.Cluster.Security.Credentials.Password = c.Password
+	config.Failover.TryCount = c.FailoverTryCount
+
+	if c.TLS.Enabled {
+		if err := c.TLS.apply(&config.Cluster.Network.SSL); err != nil {
+			return hazelcast.Config{}, err
+		}
+	}
+
+	level, _ := zerolog.ParseLevel(c.LogLevel)
+	ConfigureHazelcastLogger(&config, log.Logger, level)
+
+	return config, nil
+}
+
+func (c *HazelcastTLSConfig) apply(ssl *cluster.SSLConfig) error {
+	ssl.Enabled = true
+	ssl.ServerName = c.ServerName
+	ssl.SetTLSConfig(&tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // Explicitly opted in, e.g. for local clusters
+	})
+
+	if c.CAFile != "" {
+		if err := ssl.SetCAPath(c.CAFile); err != nil {
+			return fmt.Errorf("could not load tls ca file: %w", err)
+		}
+	}
+
+	if c.CertFile != "" {
+		if err := ssl.AddClientCertAndKeyPath(c.CertFile, c.KeyFile); err != nil {
+			return fmt.Errorf("could not load tls client certificate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// envReader reads typed environment variables and collects parsing errors.
+type envReader struct {
+	prefix string
+	errs   []error
+}
+
+func (r *envReader) lookup(name string) (string, bool) {
+	return os.LookupEnv(r.prefix + name)
+}
+
+func (r *envReader) fail(name string, err error) {
+	r.errs = append(r.errs, fmt.Errorf("invalid value of %s%s: %w", r.prefix, name, err))
+}
+
+func (r *envReader) string(name string, target *string) {
+	if value, ok := r.lookup(name); ok {
+		*target = value
+	}
+}
+
+func (r *envReader) list(name string, target *[]string) {
+	value, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+
+	*target = (*target)[:0:0]
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*target = append(*target, item)
+		}
+	}
+}
+
+func (r *envReader) bool(name string, target *bool) {
+	if value, ok := r.lookup(name); ok {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			r.fail(name, err)
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (r *envReader) int(name string, target *int) {
+	if value, ok := r.lookup(name); ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			r.fail(name, err)
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (r *envReader) duration(name string, target *time.Duration) {
+	if value, ok := r.lookup(name); ok {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			r.fail(name, err)
+			return
+		}
+		*target = parsed
+	}
+}
+
+func (r *envReader) reconnectMode(name string, target *cluster.ReconnectMode) {
+	if value, ok := r.lookup(name); ok {
+		if err := target.UnmarshalText([]byte(value)); err != nil {
+			r.fail(name, err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadHazelcastConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		assertions := assert.New(t)
+
+		config, err := LoadHazelcastConfig("")
+		assertions.NoError(err)
+		assertions.Equal("horizon", config.Cluster.Name)
+		assertions.Equal([]string{"localhost:5701"}, config.Cluster.Network.Addresses)
+		assertions.False(config.Cluster.Unisocket)
+		assertions.Equal(cluster.ReconnectModeOn, config.Cluster.ConnectionStrategy.ReconnectMode)
+		assertions.Equal(5, config.Failover.TryCount)
+		if assertions.IsType(&HazelcastZerologLogger{}, config.Logger.CustomLogger) {
+			hazelcastLogger := config.Logger.CustomLogger.(*HazelcastZerologLogger)
+			assertions.Equal(zerolog.InfoLevel, hazelcastLogger.level)
+			assertions.Equal("horizon", hazelcastLogger.cluster)
+		}
+	})
+
+	t.Run("File and environment", func(t *testing.T) {
+		assertions := assert.New(t)
+
+		path := writeConfigFile(t, "hazelcast.yaml", `
+clusterName: file
+addresses: [hazelcast-0:5701, hazelcast-1:5701]
+smartRouting: false
+reconnectMode: "off"
+invocationTimeout: 30s
+username: horizon
+password: secret
+logLevel: warn
+`)
+		t.Setenv("HAZELCAST_CLUSTER_NAME", "env")
+		t.Setenv("HAZELCAST_HEARTBEAT_INTERVAL", "2s")
+
+		config, err := LoadHazelcastConfig(path)
+		assertions.NoError(err)
+		assertions.Equal("env", config.Cluster.Name)
+		assertions.Equal([]string{"hazelcast-0:5701", "hazelcast-1:5701"}, config.Cluster.Network.Addresses)
+		assertions.True(config.Cluster.Unisocket)
+		assertions.Equal(cluster.ReconnectModeOff, config.Cluster.ConnectionStrategy.ReconnectMode)
+		assertions.Equal(types.Duration(30*time.Second), config.Cluster.InvocationTimeout)
+		assertions.Equal(types.Duration(2*time.Second), config.Cluster.HeartbeatInterval)
+		assertions.Equal("horizon", config.Cluster.Security.Credentials.Username)
+		assertions.Equal("secret", config.Cluster.Security.Credentials.Password)
+		if assertions.IsType(&HazelcastZerologLogger{}, config.Logger.CustomLogger) {
+			assertions.Equal(zerolog.WarnLevel, config.Logger.CustomLogger.(*HazelcastZerologLogger).level)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		assertions := assert.New(t)
+
+		path := writeConfigFile(t, "hazelcast.json", `{"clusterName": "json", "tls": {"enabled": true, "serverName": "hazelcast"}}`)
+
+		config, err := LoadHazelcastConfig(path)
+		assertions.NoError(err)
+		assertions.Equal("json", config.Cluster.Name)
+		assertions.True(config.Cluster.Network.SSL.Enabled)
+		assertions.Equal("hazelcast", config.Cluster.Network.SSL.TLSConfig().ServerName)
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		path := writeConfigFile(t, "hazelcast.yaml", "clusterNmae: typo\n")
+
+		_, err := LoadHazelcastConfig(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid environment", func(t *testing.T) {
+		t.Setenv("HAZELCAST_SMART_ROUTING", "maybe")
+		t.Setenv("HAZELCAST_RECONNECT_MODE", "sometimes")
+
+		_, err := LoadHazelcastConfig("")
+		assert.ErrorContains(t, err, "HAZELCAST_SMART_ROUTING")
+		assert.ErrorContains(t, err, "HAZELCAST_RECONNECT_MODE")
+	})
+}
+
+func TestHazelcastConfig_Validate(t *testing.T) {
+	assertions := assert.New(t)
+
+	config := NewHazelcastConfig()
+	assertions.NoError(config.Validate())
+
+	config.ClusterName = ""
+	config.Addresses = nil
+	config.InvocationTimeout = -time.Second
+	config.Password = "secret"
+	config.TLS.CertFile = "client.pem"
+	config.LogLevel = "loud"
+
+	err := config.Validate()
+	assertions.ErrorIs(err, ErrInvalidHazelcastConfig)
+	for _, message := range []string{"cluster name", "address", "invocation timeout", "username", "tls", "log level"} {
+		assertions.ErrorContains(err, message)
+	}
+}
+
+func writeConfigFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
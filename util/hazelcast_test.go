@@ -0,0 +1,63 @@
+// Copyright 2025 Deutsche Telekom AG
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHazelcastZerologLogger(t *testing.T) {
+	assertions := assert.New(t)
+
+	var buffer bytes.Buffer
+	config := hazelcast.NewConfig()
+	config.Cluster.Name = "horizon"
+	config.Logger.Level = logger.DebugLevel
+	ConfigureHazelcastLogger(&config, zerolog.New(&buffer), zerolog.InfoLevel)
+	assertions.NoError(config.Validate())
+
+	hazelcastLogger := config.Logger.CustomLogger
+	hazelcastLogger.Log(logger.WeightDebug, func() string {
+		return "filtered"
+	})
+	assertions.Zero(buffer.Len())
+
+	hazelcastLogger.Log(logger.WeightWarn, func() string {
+		return "member left"
+	})
+
+	var entry map[string]string
+	assertions.NoError(json.Unmarshal(buffer.Bytes(), &entry))
+	assertions.Equal(map[string]string{
+		"level":     "warn",
+		"component": "hazelcast",
+		"weight":    "warn",
+		"cluster":   "horizon",
+		"message":   "member left",
+	}, entry)
+}
+
+func TestNewHazelcastZerologLogger(t *testing.T) {
+	assertions := assert.New(t)
+
+	var buffer bytes.Buffer
+	hazelcastLogger := NewHazelcastZerologLogger(zerolog.New(&buffer), zerolog.TraceLevel)
+	hazelcastLogger.Log(logger.WeightTrace, func() string {
+		return "invocation"
+	})
+
+	var entry map[string]string
+	assertions.NoError(json.Unmarshal(buffer.Bytes(), &entry))
+	assertions.Equal("debug", entry["level"])
+	assertions.Equal("trace", entry["weight"])
+	assertions.NotContains(entry, "cluster")
+}